@@ -0,0 +1,69 @@
+package words
+
+import "container/heap"
+
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+func CountWords(text string) map[string]int {
+	return defaultTokenizer.Count(text)
+}
+
+func TopWords(frequencies map[string]int, n int) []WordCount {
+	return TopWordsFunc(frequencies, n, ByCount)
+}
+
+// ByCount orders words by count descending, breaking ties by word ascending.
+func ByCount(a, b WordCount) bool {
+	if a.Count != b.Count {
+		return a.Count > b.Count
+	}
+	return a.Word < b.Word
+}
+
+// TopWordsFunc returns the first n words according to less in O(m log n),
+// keeping only n candidates in a heap instead of sorting all m words.
+func TopWordsFunc(frequencies map[string]int, n int, less func(a, b WordCount) bool) []WordCount {
+	if n <= 0 {
+		return []WordCount{}
+	}
+
+	h := &wordHeap{less: less}
+	for k, v := range frequencies {
+		wc := WordCount{Word: k, Count: v}
+		if h.Len() < n {
+			heap.Push(h, wc)
+		} else if less(wc, h.words[0]) {
+			h.words[0] = wc
+			heap.Fix(h, 0)
+		}
+	}
+
+	words := make([]WordCount, h.Len())
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = heap.Pop(h).(WordCount)
+	}
+	return words
+}
+
+// wordHeap keeps the worst of the selected words at the root
+type wordHeap struct {
+	words []WordCount
+	less  func(a, b WordCount) bool
+}
+
+func (h *wordHeap) Len() int { return len(h.words) }
+
+func (h *wordHeap) Less(i, j int) bool { return h.less(h.words[j], h.words[i]) }
+
+func (h *wordHeap) Swap(i, j int) { h.words[i], h.words[j] = h.words[j], h.words[i] }
+
+func (h *wordHeap) Push(x any) { h.words = append(h.words, x.(WordCount)) }
+
+func (h *wordHeap) Pop() any {
+	wc := h.words[len(h.words)-1]
+	h.words = h.words[:len(h.words)-1]
+	return wc
+}
@@ -1,51 +1,11 @@
 package words
 
 import (
-	"fmt"
 	"maps"
 	"slices"
-	"strings"
 	"testing"
 )
 
-type WordCount struct {
-	Word  string
-	Count int
-}
-
-func CountWords(text string) map[string]int {
-	parts := strings.Fields(text)
-	result := map[string]int{}
-	for _, p := range parts {
-		trimmed := strings.Trim(p, "!.,")
-		result[trimmed] += 1
-	}
-	return result
-}
-
-func TopWords(frequencies map[string]int, n int) []WordCount {
-	if n <= 0 {
-		return []WordCount{}
-	}
-
-	words := []WordCount{}
-	for k, v := range frequencies {
-		words = append(words, WordCount{
-			Word:  k,
-			Count: v,
-		})
-	}
-
-	slices.SortFunc(words, func(a, b WordCount) int {
-		return b.Count - a.Count
-	})
-
-	if len(words) <= n {
-		return words
-	}
-	return words[:n]
-}
-
 func TestCountWords(t *testing.T) {
 	text := "Hello world! Hello Go programming. Go is great, Go is powerful."
 	expected := map[string]int{
@@ -54,28 +14,44 @@ func TestCountWords(t *testing.T) {
 	}
 	result := CountWords(text)
 	if !maps.Equal(result, expected) {
-		fmt.Printf("Got: %v\n", result)
+		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
 
 func TestTopWords(t *testing.T) {
 	frequencies := map[string]int{"go": 3, "hello": 2, "is": 2, "world": 1}
 	result := TopWords(frequencies, 2)
-	// Should return [{"go", 3}, {"hello", 2}] or [{"go", 3}, {"is", 2}]
-	// (either "hello" or "is" for second place is acceptable)
+	// Ties are broken alphabetically, so "hello" always beats "is"
+	expected := []WordCount{{"go", 3}, {"hello", 2}}
+
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestTopWordsDeterministic(t *testing.T) {
+	frequencies := map[string]int{"d": 1, "c": 1, "b": 1, "a": 1, "e": 2}
+	expected := []WordCount{{"e", 2}, {"a", 1}, {"b", 1}, {"c", 1}, {"d", 1}}
 
-	if len(result) != 2 {
-		t.Fail()
-		return
+	for range 20 {
+		result := TopWords(frequencies, 10)
+		if !slices.Equal(result, expected) {
+			t.Fatalf("Expected %v, got %v", expected, result)
+		}
 	}
+}
+
+func TestTopWordsFunc(t *testing.T) {
+	frequencies := map[string]int{"go": 3, "hello": 2, "is": 2, "world": 1}
+	alphabetical := func(a, b WordCount) bool { return a.Word < b.Word }
 
-	if result[0].Word != "go" {
-		t.Fail()
-		return
+	result := TopWordsFunc(frequencies, 3, alphabetical)
+	expected := []WordCount{{"go", 3}, {"hello", 2}, {"is", 2}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
 	}
 
-	if result[1].Word != "hello" && result[1].Word != "is" {
-		t.Fail()
-		return
+	if result := TopWordsFunc(frequencies, 0, alphabetical); len(result) != 0 {
+		t.Errorf("Expected no words for n=0, got %v", result)
 	}
 }
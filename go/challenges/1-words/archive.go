@@ -0,0 +1,238 @@
+package words
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"slices"
+)
+
+type ArchiveOptions struct {
+	Options
+	Include []string // Entry patterns to count, see path.Match; empty means all
+	Exclude []string // Entry patterns to skip, even if included
+}
+
+type ArchiveCounts struct {
+	Files map[string]map[string]int // Keyed by file name, or entry path inside the archive
+	Total map[string]int
+}
+
+// CountArchive counts words in r, which may be plain text, gzip, zip, tar
+// or a gzipped tar. The format is detected from the content, so name is
+// only used as the key of plain and gzipped files. Gzipped entries are
+// decompressed, but entries that are archives themselves, or compressed
+// twice, are skipped rather than opened, so nesting can't exhaust memory.
+//
+// Zip needs random access, so a zip stream is read into memory; use
+// CountArchiveFile to avoid that.
+func CountArchive(name string, r io.Reader, opts ArchiveOptions) (ArchiveCounts, error) {
+	if err := opts.validate(); err != nil {
+		return ArchiveCounts{}, err
+	}
+	result := ArchiveCounts{Files: map[string]map[string]int{}, Total: map[string]int{}}
+	if err := result.count(name, r, opts); err != nil {
+		return ArchiveCounts{}, err
+	}
+	return result, nil
+}
+
+func CountArchiveFile(filename string, opts ArchiveOptions) (ArchiveCounts, error) {
+	if err := opts.validate(); err != nil {
+		return ArchiveCounts{}, err
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return ArchiveCounts{}, err
+	}
+	defer f.Close()
+
+	result := ArchiveCounts{Files: map[string]map[string]int{}, Total: map[string]int{}}
+	magic := make([]byte, len(zipMagic))
+	if _, err := f.ReadAt(magic, 0); err == nil && string(magic) == zipMagic {
+		info, err := f.Stat()
+		if err != nil {
+			return ArchiveCounts{}, err
+		}
+		zr, err := zip.NewReader(f, info.Size())
+		if err != nil {
+			return ArchiveCounts{}, fmt.Errorf("open %s: %v", filename, err)
+		}
+		if err := result.countZip(zr, opts); err != nil {
+			return ArchiveCounts{}, err
+		}
+		return result, nil
+	}
+	if err := result.count(filename, f, opts); err != nil {
+		return ArchiveCounts{}, err
+	}
+	return result, nil
+}
+
+type archiveFormat int
+
+const (
+	formatText archiveFormat = iota
+	formatGzip
+	formatZip
+	formatTar
+)
+
+const (
+	gzipMagic   = "\x1f\x8b"
+	zipMagic    = "PK\x03\x04"
+	tarMagic    = "ustar"
+	tarMagicPos = 257
+)
+
+func (o ArchiveOptions) validate() error {
+	for _, pattern := range slices.Concat(o.Include, o.Exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// match reports whether an entry should be counted. Patterns are tried
+// against the full entry path and its base name, so "*.log" matches
+// "logs/app.log".
+func (o ArchiveOptions) match(name string) bool {
+	matchAny := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, path.Base(name)); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(o.Include) > 0 && !matchAny(o.Include) {
+		return false
+	}
+	return !matchAny(o.Exclude)
+}
+
+// count detects the format of the top-level input r and counts it.
+func (c *ArchiveCounts) count(name string, r io.Reader, opts ArchiveOptions) error {
+	br, format, err := decompress(name, r)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case formatGzip:
+		return fmt.Errorf("open %s: gzip inside gzip is not supported", name)
+	case formatZip:
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return fmt.Errorf("read %s: %v", name, err)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return fmt.Errorf("open %s: %v", name, err)
+		}
+		return c.countZip(zr, opts)
+	case formatTar:
+		return c.countTar(tar.NewReader(br), name, opts)
+	}
+	return c.countText(name, br, opts)
+}
+
+// countEntry counts an archive entry, skipping anything that isn't text
+// once decompressed.
+func (c *ArchiveCounts) countEntry(name string, r io.Reader, opts ArchiveOptions) error {
+	br, format, err := decompress(name, r)
+	if err != nil {
+		return err
+	}
+	if format != formatText {
+		return nil
+	}
+	return c.countText(name, br, opts)
+}
+
+func (c *ArchiveCounts) countText(name string, r io.Reader, opts ArchiveOptions) error {
+	counts, err := CountReaderWithOptions(r, opts.Options)
+	if err != nil {
+		return fmt.Errorf("read %s: %v", name, err)
+	}
+	if c.Files[name] == nil {
+		c.Files[name] = map[string]int{}
+	}
+	mergeCounts(c.Files[name], counts)
+	mergeCounts(c.Total, counts)
+	return nil
+}
+
+func (c *ArchiveCounts) countZip(zr *zip.Reader, opts ArchiveOptions) error {
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !opts.match(f.Name) {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("open %s: %v", f.Name, err)
+		}
+		err = c.countEntry(f.Name, rc, opts)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ArchiveCounts) countTar(tr *tar.Reader, name string, opts ArchiveOptions) error {
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read %s: %v", name, err)
+		}
+		if header.Typeflag != tar.TypeReg || !opts.match(header.Name) {
+			continue
+		}
+		if err := c.countEntry(header.Name, tr, opts); err != nil {
+			return err
+		}
+	}
+}
+
+// decompress detects the format of r, removing one layer of gzip first.
+// A gzip.Reader holds no resources, so it isn't closed.
+func decompress(name string, r io.Reader) (*bufio.Reader, archiveFormat, error) {
+	br, format := detectFormat(r)
+	if format != formatGzip {
+		return br, format, nil
+	}
+	gz, err := gzip.NewReader(br)
+	if err != nil {
+		return nil, 0, fmt.Errorf("open %s: %v", name, err)
+	}
+	br, format = detectFormat(gz)
+	return br, format, nil
+}
+
+func detectFormat(r io.Reader) (*bufio.Reader, archiveFormat) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(tarMagicPos + len(tarMagic)) // Short input is plain text
+	switch {
+	case bytes.HasPrefix(head, []byte(gzipMagic)):
+		return br, formatGzip
+	case bytes.HasPrefix(head, []byte(zipMagic)):
+		return br, formatZip
+	case len(head) >= tarMagicPos+len(tarMagic) && string(head[tarMagicPos:]) == tarMagic:
+		return br, formatTar
+	}
+	return br, formatText
+}
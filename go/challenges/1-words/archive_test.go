@@ -0,0 +1,180 @@
+package words
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var archiveFiles = []struct{ name, text string }{
+	{"logs/app.log", "error timeout error"},
+	{"logs/db.log", "timeout retry"},
+	{"notes/readme.md", "hello world"},
+}
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write(data)
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func tarBytes(t *testing.T) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	tw.WriteHeader(&tar.Header{Name: "logs/", Typeflag: tar.TypeDir, Mode: 0o755})
+	for _, f := range archiveFiles {
+		tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.text))})
+		tw.Write([]byte(f.text))
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func zipBytes(t *testing.T, extra map[string][]byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	zw.Create("logs/")
+	for _, f := range archiveFiles {
+		w, _ := zw.Create(f.name)
+		w.Write([]byte(f.text))
+	}
+	for name, data := range extra {
+		w, _ := zw.Create(name)
+		w.Write(data)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestCountArchive(t *testing.T) {
+	inputs := map[string][]byte{
+		"logs.tar":    tarBytes(t),
+		"logs.tar.gz": gzipBytes(t, tarBytes(t)),
+		"logs.zip":    zipBytes(t, nil),
+	}
+	expectedFiles := map[string]map[string]int{
+		"logs/app.log":    {"error": 2, "timeout": 1},
+		"logs/db.log":     {"timeout": 1, "retry": 1},
+		"notes/readme.md": {"hello": 1, "world": 1},
+	}
+	expectedTotal := map[string]int{"error": 2, "timeout": 2, "retry": 1, "hello": 1, "world": 1}
+
+	for name, data := range inputs {
+		result, err := CountArchive(name, bytes.NewReader(data), ArchiveOptions{})
+		if err != nil {
+			t.Fatalf("%s: CountArchive returned error: %v", name, err)
+		}
+		if !maps.EqualFunc(result.Files, expectedFiles, maps.Equal) {
+			t.Errorf("%s: expected files %v, got %v", name, expectedFiles, result.Files)
+		}
+		if !maps.Equal(result.Total, expectedTotal) {
+			t.Errorf("%s: expected total %v, got %v", name, expectedTotal, result.Total)
+		}
+	}
+}
+
+func TestCountArchivePlainAndGzip(t *testing.T) {
+	text := "Hello world! Hello Go."
+	for name, data := range map[string][]byte{"a.txt": []byte(text), "a.txt.gz": gzipBytes(t, []byte(text))} {
+		result, err := CountArchive(name, bytes.NewReader(data), ArchiveOptions{})
+		if err != nil {
+			t.Fatalf("%s: CountArchive returned error: %v", name, err)
+		}
+		if !maps.Equal(result.Files[name], CountWords(text)) || !maps.Equal(result.Total, CountWords(text)) {
+			t.Errorf("%s: expected %v, got %v", name, CountWords(text), result)
+		}
+	}
+}
+
+func TestCountArchiveFilters(t *testing.T) {
+	data := zipBytes(t, nil)
+	tests := []struct {
+		include, exclude []string
+		expected         []string
+	}{
+		{nil, nil, []string{"logs/app.log", "logs/db.log", "notes/readme.md"}},
+		{[]string{"*.log"}, nil, []string{"logs/app.log", "logs/db.log"}},
+		{[]string{"logs/*"}, []string{"db.*"}, []string{"logs/app.log"}},
+		{nil, []string{"notes/*"}, []string{"logs/app.log", "logs/db.log"}},
+	}
+	for _, tt := range tests {
+		opts := ArchiveOptions{Include: tt.include, Exclude: tt.exclude}
+		result, err := CountArchive("logs.zip", bytes.NewReader(data), opts)
+		if err != nil {
+			t.Fatalf("CountArchive returned error: %v", err)
+		}
+		if names := slices.Sorted(maps.Keys(result.Files)); !slices.Equal(names, tt.expected) {
+			t.Errorf("include %v exclude %v: expected %v, got %v", tt.include, tt.exclude, tt.expected, names)
+		}
+	}
+
+	if _, err := CountArchive("logs.zip", bytes.NewReader(data), ArchiveOptions{Include: []string{"["}}); err == nil {
+		t.Errorf("Expected an error for a bad pattern")
+	}
+}
+
+func TestCountArchiveNested(t *testing.T) {
+	data := zipBytes(t, map[string][]byte{
+		"old/app.log.gz": gzipBytes(t, []byte("error again")),
+		"inner.zip":      zipBytes(t, nil),
+		"inner.tar.gz":   gzipBytes(t, tarBytes(t)),
+		"twice.gz":       gzipBytes(t, gzipBytes(t, []byte("error"))),
+	})
+	result, err := CountArchive("logs.zip", bytes.NewReader(data), ArchiveOptions{Options: Options{Filters: []TokenFilter{LengthFilter{Min: 5}}}})
+	if err != nil {
+		t.Fatalf("CountArchive returned error: %v", err)
+	}
+	if !maps.Equal(result.Files["old/app.log.gz"], map[string]int{"error": 1, "again": 1}) {
+		t.Errorf("Expected the gzipped entry to be counted, got %v", result.Files)
+	}
+	// Archives and doubly compressed entries are skipped, not opened
+	if len(result.Files) != 4 || result.Total["error"] != 3 || result.Total["retry"] != 1 {
+		t.Errorf("Expected only the text entries with error=3 and retry=1, got %v", result)
+	}
+
+	nested := gzipBytes(t, gzipBytes(t, []byte("error")))
+	if _, err := CountArchive("twice.gz", bytes.NewReader(nested), ArchiveOptions{}); err == nil {
+		t.Errorf("Expected an error for gzip inside gzip")
+	}
+}
+
+func TestCountArchiveFile(t *testing.T) {
+	dir := t.TempDir()
+	zipPath := filepath.Join(dir, "logs.zip")
+	os.WriteFile(zipPath, zipBytes(t, nil), 0o644)
+	tgzPath := filepath.Join(dir, "logs.tar.gz")
+	os.WriteFile(tgzPath, gzipBytes(t, tarBytes(t)), 0o644)
+
+	for _, path := range []string{zipPath, tgzPath} {
+		result, err := CountArchiveFile(path, ArchiveOptions{Include: []string{"*.log"}})
+		if err != nil {
+			t.Fatalf("%s: CountArchiveFile returned error: %v", path, err)
+		}
+		if len(result.Files) != 2 || result.Total["timeout"] != 2 {
+			t.Errorf("%s: expected two log files, got %v", path, result)
+		}
+	}
+
+	if _, err := CountArchiveFile(filepath.Join(dir, "missing.zip"), ArchiveOptions{}); err == nil {
+		t.Errorf("Expected an error for a missing file")
+	}
+	corrupt := append([]byte(gzipMagic), strings.Repeat("x", 20)...)
+	if _, err := CountArchive("bad.gz", bytes.NewReader(corrupt), ArchiveOptions{}); err == nil {
+		t.Errorf("Expected an error for corrupt gzip")
+	}
+}
@@ -0,0 +1,173 @@
+// Command wordcount prints the most frequent words of files, globs or stdin.
+//
+//	wordcount -top 20 -stopwords -format csv docs/*.md
+//	cat server.log | wordcount -min 100 -format jsonl
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	words "github.com/arashthr/playground/challenges/1-words"
+)
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err != nil && err != flag.ErrHelp {
+		fmt.Fprintln(os.Stderr, "wordcount:", err)
+		os.Exit(1)
+	}
+}
+
+type config struct {
+	top           int
+	caseSensitive bool
+	stopwords     bool
+	minCount      int
+	format        string
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var cfg config
+	flags := flag.NewFlagSet("wordcount", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.IntVar(&cfg.top, "top", 10, "number of words to print, 0 for all")
+	flags.BoolVar(&cfg.caseSensitive, "case", false, "count words case-sensitively")
+	flags.BoolVar(&cfg.stopwords, "stopwords", false, "drop common English stopwords")
+	flags.IntVar(&cfg.minCount, "min", 1, "only print words seen at least this many times")
+	flags.StringVar(&cfg.format, "format", "table", "output format: table, json, csv or jsonl")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: wordcount [flags] [file|glob|- ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	write, found := writers[cfg.format]
+	if !found {
+		return fmt.Errorf("unknown format %q", cfg.format)
+	}
+
+	paths, err := expandPaths(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	opts := words.Options{Tokenizer: words.NewTokenizer()}
+	opts.Tokenizer.CaseSensitive = cfg.caseSensitive
+	if cfg.stopwords {
+		opts.Filters = append(opts.Filters, words.NewStopwordFilter(words.EnglishStopwords...))
+	}
+
+	frequencies := map[string]int{}
+	for _, path := range paths {
+		counts, err := countPath(path, stdin, opts)
+		if err != nil {
+			return err
+		}
+		for word, count := range counts {
+			frequencies[word] += count
+		}
+	}
+
+	for word, count := range frequencies {
+		if count < cfg.minCount {
+			delete(frequencies, word)
+		}
+	}
+	n := cfg.top
+	if n <= 0 {
+		n = len(frequencies)
+	}
+	return write(stdout, words.TopWords(frequencies, n))
+}
+
+// expandPaths resolves globs. No arguments means stdin, written as "-".
+func expandPaths(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return []string{"-"}, nil
+	}
+	paths := []string{}
+	for _, arg := range args {
+		if arg == "-" {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %v", arg, err)
+		}
+		if len(matches) == 0 {
+			// Not a glob, or one matching nothing; let open report it
+			matches = []string{arg}
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+func countPath(path string, stdin io.Reader, opts words.Options) (map[string]int, error) {
+	if path == "-" {
+		return words.CountReaderWithOptions(stdin, opts)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	counts, err := words.CountReaderWithOptions(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", path, err)
+	}
+	return counts, nil
+}
+
+var writers = map[string]func(io.Writer, []words.WordCount) error{
+	"table": writeTable,
+	"json":  writeJSON,
+	"csv":   writeCSV,
+	"jsonl": writeJSONLines,
+}
+
+func writeTable(w io.Writer, counts []words.WordCount) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "WORD\tCOUNT")
+	for _, wc := range counts {
+		fmt.Fprintf(tw, "%s\t%d\n", wc.Word, wc.Count)
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, counts []words.WordCount) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(counts)
+}
+
+func writeCSV(w io.Writer, counts []words.WordCount) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"word", "count"})
+	for _, wc := range counts {
+		cw.Write([]string{wc.Word, strconv.Itoa(wc.Count)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSONLines(w io.Writer, counts []words.WordCount) error {
+	encoder := json.NewEncoder(w)
+	for _, wc := range counts {
+		if err := encoder.Encode(wc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunFormats(t *testing.T) {
+	input := "Hello world! Hello Go programming. Go is great, Go is powerful. The end."
+
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"-top", "2"}, "WORD   COUNT\ngo     3\nhello  2\n"},
+		{[]string{"-top", "2", "-format", "csv"}, "word,count\ngo,3\nhello,2\n"},
+		{[]string{"-min", "3", "-format", "jsonl"}, "{\"word\":\"go\",\"count\":3}\n"},
+		{[]string{"-top", "1", "-format", "json"}, "[\n  {\n    \"word\": \"go\",\n    \"count\": 3\n  }\n]\n"},
+		{[]string{"-stopwords", "-min", "2", "-format", "csv"}, "word,count\ngo,3\nhello,2\n"},
+		{[]string{"-case", "-top", "1", "-format", "csv", "-"}, "word,count\nGo,3\n"},
+		{[]string{"-case", "-stopwords", "-top", "0", "-format", "csv"},
+			"word,count\nGo,3\nHello,2\nend,1\ngreat,1\npowerful,1\nprogramming,1\nworld,1\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			err := run(tt.args, strings.NewReader(input), &stdout, &stderr)
+			if err != nil {
+				t.Fatalf("run returned error: %v (%s)", err, stderr.String())
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunFiles(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "a.txt"), []byte("go go rust"), 0o644)
+	os.WriteFile(filepath.Join(dir, "b.txt"), []byte("go zig"), 0o644)
+	os.WriteFile(filepath.Join(dir, "c.md"), []byte("ignored ignored ignored ignored"), 0o644)
+
+	var stdout, stderr strings.Builder
+	err := run([]string{"-format", "csv", filepath.Join(dir, "*.txt")}, strings.NewReader(""), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("run returned error: %v", err)
+	}
+	expected := "word,count\ngo,3\nrust,1\nzig,1\n"
+	if stdout.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, stdout.String())
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	var stdout, stderr strings.Builder
+	if err := run([]string{"-format", "xml"}, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	if err := run([]string{"missing.txt"}, strings.NewReader(""), &stdout, &stderr); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
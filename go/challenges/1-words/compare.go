@@ -0,0 +1,181 @@
+package words
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+type TermChiSquare struct {
+	Word      string
+	Before    int
+	After     int
+	ChiSquare float64 // Pearson's statistic with one degree of freedom
+}
+
+type TermChange struct {
+	Word     string
+	Before   int
+	After    int
+	LogRatio float64 // log2 of the smoothed relative frequency after over before
+}
+
+// KLDivergence returns the Kullback-Leibler divergence of p from q in bits.
+// Every term of either map gets alpha added to its count so terms missing
+// from q don't make the divergence infinite. With alpha 0 it may be +Inf.
+func KLDivergence(p, q map[string]int, alpha float64) float64 {
+	pd, qd := smoothedDistributions(p, q, alpha)
+	kl := 0.0
+	for term, pp := range pd {
+		if pp == 0 {
+			continue
+		}
+		if qd[term] == 0 {
+			return math.Inf(1)
+		}
+		kl += pp * math.Log2(pp/qd[term])
+	}
+	return kl
+}
+
+// JSDivergence returns the Jensen-Shannon divergence in bits. It is
+// symmetric, always finite and ranges from 0 for identical distributions to
+// 1 for ones without shared terms, so no smoothing is needed. A map with no
+// counts shares no terms, so it is 1 from a non-empty map and 0 from
+// another empty one.
+func JSDivergence(p, q map[string]int) float64 {
+	emptyP, emptyQ := sumCounts(p) == 0, sumCounts(q) == 0
+	if emptyP || emptyQ {
+		if emptyP && emptyQ {
+			return 0
+		}
+		return 1
+	}
+	pd, qd := smoothedDistributions(p, q, 0)
+	js := 0.0
+	for term := range unionTerms(p, q) {
+		m := (pd[term] + qd[term]) / 2
+		if pd[term] > 0 {
+			js += pd[term] * math.Log2(pd[term]/m) / 2
+		}
+		if qd[term] > 0 {
+			js += qd[term] * math.Log2(qd[term]/m) / 2
+		}
+	}
+	return js
+}
+
+// ChiSquare tests each term for a change in relative frequency between
+// before and after, most significant first. Values above 3.84 are
+// significant at p < 0.05, above 10.83 at p < 0.001.
+func ChiSquare(before, after map[string]int) []TermChiSquare {
+	totalBefore, totalAfter := float64(sumCounts(before)), float64(sumCounts(after))
+	total := totalBefore + totalAfter
+
+	result := []TermChiSquare{}
+	for term := range unionTerms(before, after) {
+		b, a := float64(before[term]), float64(after[term])
+		observed := [4]float64{b, a, totalBefore - b, totalAfter - a}
+		expected := [4]float64{
+			(a + b) * totalBefore / total,
+			(a + b) * totalAfter / total,
+			(total - a - b) * totalBefore / total,
+			(total - a - b) * totalAfter / total,
+		}
+		chi := 0.0
+		for i, o := range observed {
+			if expected[i] > 0 {
+				chi += (o - expected[i]) * (o - expected[i]) / expected[i]
+			}
+		}
+		result = append(result, TermChiSquare{Word: term, Before: before[term], After: after[term], ChiSquare: chi})
+	}
+	slices.SortFunc(result, func(x, y TermChiSquare) int {
+		if x.ChiSquare != y.ChiSquare {
+			if x.ChiSquare > y.ChiSquare {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(x.Word, y.Word)
+	})
+	return result
+}
+
+// Gainers returns the n terms whose relative frequency grew the most,
+// smoothed with alpha so brand new terms get a finite ratio.
+func Gainers(before, after map[string]int, n int, alpha float64) []TermChange {
+	return topChanges(before, after, n, alpha, 1)
+}
+
+// Losers returns the n terms whose relative frequency shrank the most.
+func Losers(before, after map[string]int, n int, alpha float64) []TermChange {
+	return topChanges(before, after, n, alpha, -1)
+}
+
+func topChanges(before, after map[string]int, n int, alpha float64, sign float64) []TermChange {
+	bd, ad := smoothedDistributions(before, after, alpha)
+	changes := []TermChange{}
+	for term := range bd {
+		if bd[term] == 0 || ad[term] == 0 {
+			continue // Only possible without smoothing
+		}
+		ratio := math.Log2(ad[term] / bd[term])
+		if ratio*sign > 0 {
+			changes = append(changes, TermChange{Word: term, Before: before[term], After: after[term], LogRatio: ratio})
+		}
+	}
+	slices.SortFunc(changes, func(x, y TermChange) int {
+		if x.LogRatio != y.LogRatio {
+			if x.LogRatio*sign > y.LogRatio*sign {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(x.Word, y.Word)
+	})
+	if len(changes) > max(n, 0) {
+		changes = changes[:max(n, 0)]
+	}
+	return changes
+}
+
+// smoothedDistributions turns counts into probabilities over the union of
+// both vocabularies, adding alpha to every count.
+func smoothedDistributions(p, q map[string]int, alpha float64) (map[string]float64, map[string]float64) {
+	terms := unionTerms(p, q)
+	vocabulary := float64(len(terms))
+	totalP := float64(sumCounts(p)) + alpha*vocabulary
+	totalQ := float64(sumCounts(q)) + alpha*vocabulary
+
+	pd := make(map[string]float64, len(terms))
+	qd := make(map[string]float64, len(terms))
+	for term := range terms {
+		if totalP > 0 {
+			pd[term] = (float64(p[term]) + alpha) / totalP
+		}
+		if totalQ > 0 {
+			qd[term] = (float64(q[term]) + alpha) / totalQ
+		}
+	}
+	return pd, qd
+}
+
+func unionTerms(p, q map[string]int) map[string]struct{} {
+	terms := make(map[string]struct{}, len(p)+len(q))
+	for term := range p {
+		terms[term] = struct{}{}
+	}
+	for term := range q {
+		terms[term] = struct{}{}
+	}
+	return terms
+}
+
+func sumCounts(frequencies map[string]int) int {
+	total := 0
+	for _, count := range frequencies {
+		total += count
+	}
+	return total
+}
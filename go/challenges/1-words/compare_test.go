@@ -0,0 +1,77 @@
+package words
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDivergence(t *testing.T) {
+	p := map[string]int{"go": 3, "rust": 1}
+	q := map[string]int{"go": 1, "rust": 3}
+	disjoint := map[string]int{"zig": 4}
+
+	if kl := KLDivergence(p, p, 0); kl != 0 {
+		t.Errorf("Expected KL(p, p) = 0, got %f", kl)
+	}
+	// 0.75*log2(3) + 0.25*log2(1/3)
+	if kl := KLDivergence(p, q, 0); math.Abs(kl-0.5*math.Log2(3)) > 1e-9 {
+		t.Errorf("Expected KL(p, q) = %f, got %f", 0.5*math.Log2(3), kl)
+	}
+	if kl := KLDivergence(p, disjoint, 0); !math.IsInf(kl, 1) {
+		t.Errorf("Expected infinite KL without smoothing, got %f", kl)
+	}
+	if kl := KLDivergence(p, disjoint, 1); math.IsInf(kl, 0) || kl <= 0 {
+		t.Errorf("Expected finite positive KL with smoothing, got %f", kl)
+	}
+
+	if js := JSDivergence(p, p); js != 0 {
+		t.Errorf("Expected JS(p, p) = 0, got %f", js)
+	}
+	if js := JSDivergence(p, disjoint); math.Abs(js-1) > 1e-9 {
+		t.Errorf("Expected JS of disjoint maps = 1, got %f", js)
+	}
+	if a, b := JSDivergence(p, q), JSDivergence(q, p); math.Abs(a-b) > 1e-9 || a <= 0 {
+		t.Errorf("Expected symmetric positive JS, got %f and %f", a, b)
+	}
+	empty := map[string]int{}
+	if a, b := JSDivergence(empty, p), JSDivergence(p, empty); a != 1 || b != 1 {
+		t.Errorf("Expected JS of an empty map = 1 in either position, got %f and %f", a, b)
+	}
+	if js := JSDivergence(empty, nil); js != 0 {
+		t.Errorf("Expected JS of two empty maps = 0, got %f", js)
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	before := map[string]int{"go": 50, "rust": 50, "the": 100}
+	after := map[string]int{"go": 90, "rust": 10, "the": 100}
+
+	result := ChiSquare(before, after)
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 terms, got %v", result)
+	}
+	if result[2].Word != "the" || result[2].ChiSquare > 1e-9 {
+		t.Errorf("Expected unchanged 'the' last with chi-square 0, got %+v", result[2])
+	}
+	if result[0].ChiSquare < 10.83 {
+		t.Errorf("Expected a significant change at the top, got %+v", result[0])
+	}
+}
+
+func TestGainersLosers(t *testing.T) {
+	before := map[string]int{"go": 10, "rust": 10, "the": 20, "perl": 5}
+	after := map[string]int{"go": 30, "rust": 10, "the": 20, "zig": 5}
+
+	gainers := Gainers(before, after, 10, 1)
+	if len(gainers) != 2 || gainers[0].Word != "zig" || gainers[1].Word != "go" {
+		t.Errorf("Expected gainers [zig go], got %v", gainers)
+	}
+	if gainers[0].Before != 0 || gainers[0].After != 5 {
+		t.Errorf("Expected raw counts 0 -> 5 for zig, got %+v", gainers[0])
+	}
+
+	losers := Losers(before, after, 1, 1)
+	if len(losers) != 1 || losers[0].Word != "perl" || losers[0].LogRatio >= 0 {
+		t.Errorf("Expected perl to be the biggest loser, got %v", losers)
+	}
+}
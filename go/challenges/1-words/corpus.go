@@ -0,0 +1,146 @@
+package words
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+type TermWeight struct {
+	WordCount         // Count is the raw frequency in the document
+	Weight    float64 // TF-IDF weight
+}
+
+// Corpus keeps per-document term frequencies and how many documents each
+// term appears in, so terms can be weighted by how distinctive they are.
+type Corpus struct {
+	opts Options
+	docs []corpusDocument
+	ids  map[string]int
+	df   map[string]int
+}
+
+type corpusDocument struct {
+	id          string
+	frequencies map[string]int
+	total       int
+}
+
+func NewCorpus(opts Options) *Corpus {
+	return &Corpus{
+		opts: opts,
+		ids:  map[string]int{},
+		df:   map[string]int{},
+	}
+}
+
+func (c *Corpus) Add(id, text string) error {
+	if _, found := c.ids[id]; found {
+		return fmt.Errorf("document %q already in corpus", id)
+	}
+	doc := corpusDocument{id: id, frequencies: CountWordsWithOptions(text, c.opts)}
+	for term, count := range doc.frequencies {
+		doc.total += count
+		c.df[term] += 1
+	}
+	c.ids[id] = len(c.docs)
+	c.docs = append(c.docs, doc)
+	return nil
+}
+
+func (c *Corpus) Len() int {
+	return len(c.docs)
+}
+
+// DocumentFrequency returns the number of documents containing term.
+func (c *Corpus) DocumentFrequency(term string) int {
+	return c.df[term]
+}
+
+// IDF uses the smoothed ln((1+N)/(1+df)) + 1, which stays positive for terms
+// found in every document.
+func (c *Corpus) IDF(term string) float64 {
+	return math.Log(float64(1+len(c.docs))/float64(1+c.df[term])) + 1
+}
+
+func (c *Corpus) TFIDF(id string) (map[string]float64, error) {
+	doc, err := c.document(id)
+	if err != nil {
+		return nil, err
+	}
+	weights := make(map[string]float64, len(doc.frequencies))
+	for term, count := range doc.frequencies {
+		weights[term] = float64(count) / float64(doc.total) * c.IDF(term)
+	}
+	return weights, nil
+}
+
+// TopTerms returns the n terms with the highest TF-IDF weight in a document.
+func (c *Corpus) TopTerms(id string, n int) ([]TermWeight, error) {
+	weights, err := c.TFIDF(id)
+	if err != nil {
+		return nil, err
+	}
+	doc := c.docs[c.ids[id]]
+
+	terms := make([]TermWeight, 0, len(weights))
+	for term, weight := range weights {
+		terms = append(terms, TermWeight{
+			WordCount: WordCount{Word: term, Count: doc.frequencies[term]},
+			Weight:    weight,
+		})
+	}
+	slices.SortFunc(terms, func(a, b TermWeight) int {
+		if a.Weight != b.Weight {
+			if a.Weight > b.Weight {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	if n < 0 {
+		n = 0
+	}
+	if len(terms) <= n {
+		return terms, nil
+	}
+	return terms[:n], nil
+}
+
+// Similarity returns the cosine similarity of two documents' TF-IDF vectors.
+func (c *Corpus) Similarity(a, b string) (float64, error) {
+	wa, err := c.TFIDF(a)
+	if err != nil {
+		return 0, err
+	}
+	wb, err := c.TFIDF(b)
+	if err != nil {
+		return 0, err
+	}
+	return cosine(wa, wb), nil
+}
+
+func (c *Corpus) document(id string) (*corpusDocument, error) {
+	i, found := c.ids[id]
+	if !found {
+		return nil, fmt.Errorf("unknown document %q", id)
+	}
+	return &c.docs[i], nil
+}
+
+func cosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, wa := range a {
+		dot += wa * b[term]
+		normA += wa * wa
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -0,0 +1,83 @@
+package words
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestCorpus(t *testing.T) *Corpus {
+	t.Helper()
+	corpus := NewCorpus(Options{Filters: []TokenFilter{NewStopwordFilter(EnglishStopwords...)}})
+	docs := map[string]string{
+		"pool":    "The worker pool runs jobs on goroutines. Each worker reads jobs from a channel.",
+		"limiter": "The rate limiter uses a token bucket. Each bucket refills tokens every second.",
+		"fetcher": "The fetcher uses a worker pool of goroutines to fetch pages from a channel of URLs.",
+	}
+	for _, id := range []string{"pool", "limiter", "fetcher"} {
+		if err := corpus.Add(id, docs[id]); err != nil {
+			t.Fatalf("Add(%q) returned error: %v", id, err)
+		}
+	}
+	return corpus
+}
+
+func TestCorpusTopTerms(t *testing.T) {
+	corpus := newTestCorpus(t)
+
+	if corpus.Len() != 3 {
+		t.Errorf("Expected 3 documents, got %d", corpus.Len())
+	}
+	if df := corpus.DocumentFrequency("worker"); df != 2 {
+		t.Errorf("Expected 'worker' in 2 documents, got %d", df)
+	}
+
+	top, err := corpus.TopTerms("limiter", 2)
+	if err != nil {
+		t.Fatalf("TopTerms returned error: %v", err)
+	}
+	// "bucket" is the only repeated term unique to the limiter document
+	if top[0].Word != "bucket" {
+		t.Errorf("Expected 'bucket' to be the most distinctive term, got %v", top)
+	}
+	if top[0].Count != 2 {
+		t.Errorf("Expected 'bucket' count 2, got %d", top[0].Count)
+	}
+
+	// A term shared with other documents weighs less than a unique one
+	weights, _ := corpus.TFIDF("pool")
+	if weights["worker"] >= weights["jobs"] {
+		t.Errorf("Expected shared 'worker' (%f) to weigh less than unique 'jobs' (%f)", weights["worker"], weights["jobs"])
+	}
+}
+
+func TestCorpusSimilarity(t *testing.T) {
+	corpus := newTestCorpus(t)
+
+	self, err := corpus.Similarity("pool", "pool")
+	if err != nil {
+		t.Fatalf("Similarity returned error: %v", err)
+	}
+	if math.Abs(self-1) > 1e-9 {
+		t.Errorf("Expected self-similarity 1, got %f", self)
+	}
+
+	related, _ := corpus.Similarity("pool", "fetcher")
+	unrelated, _ := corpus.Similarity("pool", "limiter")
+	if related <= unrelated {
+		t.Errorf("Expected pool~fetcher (%f) > pool~limiter (%f)", related, unrelated)
+	}
+}
+
+func TestCorpusErrors(t *testing.T) {
+	corpus := newTestCorpus(t)
+
+	if err := corpus.Add("pool", "again"); err == nil {
+		t.Error("Expected error when adding a duplicate document")
+	}
+	if _, err := corpus.TopTerms("missing", 3); err == nil {
+		t.Error("Expected error for unknown document")
+	}
+	if _, err := corpus.Similarity("pool", "missing"); err == nil {
+		t.Error("Expected error for unknown document")
+	}
+}
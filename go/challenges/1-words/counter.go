@@ -0,0 +1,90 @@
+package words
+
+import (
+	"hash/maphash"
+	"io"
+	"sync"
+)
+
+const counterShards = 32
+
+// Counter accumulates word counts from many goroutines. Words are spread
+// over independently locked shards so concurrent writers rarely contend.
+type Counter struct {
+	opts   Options
+	seed   maphash.Seed
+	shards [counterShards]counterShard
+}
+
+type counterShard struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func NewCounter(opts Options) *Counter {
+	c := &Counter{opts: opts, seed: maphash.MakeSeed()}
+	for i := range c.shards {
+		c.shards[i].counts = map[string]int{}
+	}
+	return c
+}
+
+func (c *Counter) Add(text string) {
+	c.AddCounts(CountWordsWithOptions(text, c.opts))
+}
+
+func (c *Counter) AddReader(r io.Reader) error {
+	counts, err := CountReaderWithOptions(r, c.opts)
+	if err != nil {
+		return err
+	}
+	c.AddCounts(counts)
+	return nil
+}
+
+// AddCounts adds already counted frequencies, locking each shard once.
+func (c *Counter) AddCounts(frequencies map[string]int) {
+	var grouped [counterShards]map[string]int
+	for word, count := range frequencies {
+		i := c.shardIndex(word)
+		if grouped[i] == nil {
+			grouped[i] = map[string]int{}
+		}
+		grouped[i][word] += count
+	}
+	for i, counts := range grouped {
+		if counts == nil {
+			continue
+		}
+		shard := &c.shards[i]
+		shard.mu.Lock()
+		mergeCounts(shard.counts, counts)
+		shard.mu.Unlock()
+	}
+}
+
+// Merge adds the current counts of other to c.
+func (c *Counter) Merge(other *Counter) {
+	c.AddCounts(other.Snapshot())
+}
+
+// Snapshot returns a copy of the counts. Each shard is copied atomically,
+// but writes to different shards may land while the copy is made.
+func (c *Counter) Snapshot() map[string]int {
+	result := map[string]int{}
+	for i := range c.shards {
+		shard := &c.shards[i]
+		shard.mu.Lock()
+		mergeCounts(result, shard.counts)
+		shard.mu.Unlock()
+	}
+	return result
+}
+
+func (c *Counter) Top(n int) []WordCount {
+	return TopWords(c.Snapshot(), n)
+}
+
+func (c *Counter) shardIndex(word string) int {
+	return int(maphash.String(c.seed, word) % counterShards)
+}
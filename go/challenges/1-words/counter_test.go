@@ -0,0 +1,81 @@
+package words
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCounterConcurrent(t *testing.T) {
+	counter := NewCounter(Options{})
+	text := "Hello world! Hello Go programming. Go is great, Go is powerful."
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if i%2 == 0 {
+				counter.Add(text)
+			} else if err := counter.AddReader(strings.NewReader(text)); err != nil {
+				t.Errorf("AddReader returned error: %v", err)
+			}
+			counter.Top(3) // Readers run alongside writers
+		}()
+	}
+	wg.Wait()
+
+	expected := CountWords(text)
+	for word := range expected {
+		expected[word] *= 50
+	}
+	if result := counter.Snapshot(); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	top := counter.Top(2)
+	if !slices.Equal(top, []WordCount{{"go", 150}, {"hello", 100}}) {
+		t.Errorf("Expected [{go 150} {hello 100}], got %v", top)
+	}
+}
+
+func TestCounterMerge(t *testing.T) {
+	a := NewCounter(Options{})
+	b := NewCounter(Options{Filters: []TokenFilter{NewStopwordFilter(EnglishStopwords...)}})
+	a.Add("go go rust")
+	b.Add("the go zig")
+
+	a.Merge(b)
+	expected := map[string]int{"go": 3, "rust": 1, "zig": 1}
+	if result := a.Snapshot(); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	// Merging copies; b is unchanged
+	if result := b.Snapshot(); !maps.Equal(result, map[string]int{"go": 1, "zig": 1}) {
+		t.Errorf("Expected b to be unchanged, got %v", result)
+	}
+}
+
+func TestCounterSnapshotIsCopy(t *testing.T) {
+	counter := NewCounter(Options{})
+	counter.Add("go")
+	snapshot := counter.Snapshot()
+	snapshot["go"] = 100
+	counter.Add("go")
+
+	if result := counter.Snapshot(); result["go"] != 2 {
+		t.Errorf("Expected go=2, got %d", result["go"])
+	}
+}
+
+func BenchmarkCounterAdd(b *testing.B) {
+	counter := NewCounter(Options{})
+	text := randomText(1000, 7)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			counter.Add(text)
+		}
+	})
+}
@@ -0,0 +1,357 @@
+package words
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenFilter runs between tokenizing and counting. It returns the token to
+// count, which may be rewritten, or false to drop it.
+type TokenFilter interface {
+	Filter(token string) (string, bool)
+}
+
+type FilterFunc func(token string) (string, bool)
+
+func (f FilterFunc) Filter(token string) (string, bool) {
+	return f(token)
+}
+
+type Options struct {
+	Tokenizer *Tokenizer    // nil means the default tokenizer of CountWords
+	Filters   []TokenFilter // Applied in order to every token
+	Markup    Markup        // Markup to remove before tokenizing
+	// Language is an ISO 639-1 code or AutoDetect, whose tokenizer and
+	// stopwords are used. AutoDetect falls back to no language rules when
+	// the guess is uncertain; an unregistered code also counts with none,
+	// see Validate.
+	Language string
+}
+
+func CountWordsWithOptions(text string, opts Options) map[string]int {
+	result := map[string]int{}
+	opts.each([]byte(text), func(token string) {
+		result[token] += 1
+	})
+	return result
+}
+
+func CountReaderWithOptions(r io.Reader, opts Options) (map[string]int, error) {
+	result := map[string]int{}
+	err := opts.scan(r, func(token string) {
+		result[token] += 1
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (o Options) tokenizer() *Tokenizer {
+	if o.Tokenizer == nil {
+		return defaultTokenizer
+	}
+	return o.Tokenizer
+}
+
+func (o Options) filter(token string) (string, bool) {
+	for _, f := range o.Filters {
+		var ok bool
+		if token, ok = f.Filter(token); !ok {
+			return "", false
+		}
+	}
+	return token, true
+}
+
+func (o Options) each(data []byte, fn func(token string)) {
+	if o.Markup != 0 {
+		data = []byte(CleanMarkup(string(data), o.Markup))
+	}
+	o = o.withLanguage(o.language(data))
+	o.tokenizer().each(data, func(token string) {
+		if token, ok := o.filter(token); ok {
+			fn(token)
+		}
+	})
+}
+
+// scan is the streaming counterpart of each. Markup spans lines, so input
+// that needs cleaning is read whole; the language is detected from the
+// start of the input.
+func (o Options) scan(r io.Reader, fn func(token string)) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	if o.Markup != 0 {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		o.each(data, fn)
+		return nil
+	}
+	var sample []byte
+	if o.Language == AutoDetect {
+		br := bufio.NewReaderSize(r, detectSampleSize)
+		sample, _ = br.Peek(detectSampleSize) // Read errors surface when scanning
+		r = br
+	}
+	o = o.withLanguage(o.language(sample))
+	t := o.tokenizer()
+	scanner := t.newScanner(r)
+	for scanner.Scan() {
+		if token, ok := o.filter(t.Normalize(scanner.Text())); ok {
+			fn(token)
+		}
+	}
+	return scanner.Err()
+}
+
+// StopwordFilter drops tokens in its set, ignoring case, so the lower-case
+// lists also drop "The" when the tokenizer is case sensitive.
+type StopwordFilter map[string]struct{}
+
+func NewStopwordFilter(words ...string) StopwordFilter {
+	filter := make(StopwordFilter, len(words))
+	for _, w := range words {
+		filter[strings.ToLower(w)] = struct{}{}
+	}
+	return filter
+}
+
+func (f StopwordFilter) Filter(token string) (string, bool) {
+	_, stop := f[strings.ToLower(token)]
+	return token, !stop
+}
+
+var EnglishStopwords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and",
+	"any", "are", "as", "at", "be", "because", "been", "before", "being", "below",
+	"between", "both", "but", "by", "can", "could", "did", "do", "does", "doing",
+	"don't", "down", "during", "each", "few", "for", "from", "further", "had", "has",
+	"have", "having", "he", "her", "here", "hers", "herself", "him", "himself", "his",
+	"how", "i", "if", "in", "into", "is", "isn't", "it", "it's", "its", "itself",
+	"just", "me", "more", "most", "my", "myself", "no", "nor", "not", "now", "of",
+	"off", "on", "once", "only", "or", "other", "our", "ours", "ourselves", "out",
+	"over", "own", "same", "she", "should", "so", "some", "such", "than", "that",
+	"the", "their", "theirs", "them", "themselves", "then", "there", "these", "they",
+	"this", "those", "through", "to", "too", "under", "until", "up", "very", "was",
+	"we", "were", "what", "when", "where", "which", "while", "who", "whom", "why",
+	"will", "with", "would", "you", "your", "yours", "yourself", "yourselves",
+}
+
+// LengthFilter drops tokens shorter than Min or longer than Max runes.
+// Max <= 0 means no upper limit.
+type LengthFilter struct {
+	Min int
+	Max int
+}
+
+func (f LengthFilter) Filter(token string) (string, bool) {
+	n := utf8.RuneCountInString(token)
+	return token, n >= f.Min && (f.Max <= 0 || n <= f.Max)
+}
+
+type PorterStemmer struct{}
+
+func (PorterStemmer) Filter(token string) (string, bool) {
+	return Stem(token), true
+}
+
+// Stem reduces an English word to its stem with the Porter (1980) algorithm.
+// Words that aren't all lower case ASCII letters are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	s := &stemmer{b: []byte(word)}
+	s.step1()
+	s.step2()
+	s.step3()
+	s.step4()
+	s.step5()
+	return string(s.b)
+}
+
+type stemmer struct {
+	b []byte
+}
+
+type stemRule struct {
+	suffix, replacement string
+}
+
+func (s *stemmer) cons(i int) bool {
+	switch s.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !s.cons(i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences in b[:n]
+func (s *stemmer) measure(n int) int {
+	i := 0
+	for i < n && s.cons(i) {
+		i++
+	}
+	m := 0
+	for i < n {
+		for i < n && !s.cons(i) {
+			i++
+		}
+		if i == n {
+			break
+		}
+		for i < n && s.cons(i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+func (s *stemmer) hasVowel(n int) bool {
+	for i := range n {
+		if !s.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+// doubleCons reports whether b[:n] ends with a double consonant
+func (s *stemmer) doubleCons(n int) bool {
+	return n >= 2 && s.b[n-1] == s.b[n-2] && s.cons(n-1)
+}
+
+// cvc reports whether b[:n] ends consonant-vowel-consonant where the last
+// consonant is not w, x or y, as in "hop" but not "snow"
+func (s *stemmer) cvc(n int) bool {
+	if n < 3 || !s.cons(n-1) || s.cons(n-2) || !s.cons(n-3) {
+		return false
+	}
+	last := s.b[n-1]
+	return last != 'w' && last != 'x' && last != 'y'
+}
+
+func (s *stemmer) ends(suffix string) bool {
+	return strings.HasSuffix(string(s.b), suffix)
+}
+
+func (s *stemmer) setSuffix(suffix, replacement string) {
+	s.b = append(s.b[:len(s.b)-len(suffix)], replacement...)
+}
+
+// replace applies the first rule whose suffix matches, if the remaining stem
+// has a measure above minMeasure
+func (s *stemmer) replace(rules []stemRule, minMeasure int) {
+	for _, r := range rules {
+		if s.ends(r.suffix) {
+			if s.measure(len(s.b)-len(r.suffix)) > minMeasure {
+				s.setSuffix(r.suffix, r.replacement)
+			}
+			return
+		}
+	}
+}
+
+func (s *stemmer) step1() {
+	switch {
+	case s.ends("sses"), s.ends("ies"):
+		s.b = s.b[:len(s.b)-2]
+	case s.ends("ss"):
+	case s.ends("s"):
+		s.b = s.b[:len(s.b)-1]
+	}
+
+	if s.ends("eed") {
+		if s.measure(len(s.b)-3) > 0 {
+			s.b = s.b[:len(s.b)-1]
+		}
+	} else if (s.ends("ed") && s.hasVowel(len(s.b)-2)) || (s.ends("ing") && s.hasVowel(len(s.b)-3)) {
+		if s.ends("ed") {
+			s.b = s.b[:len(s.b)-2]
+		} else {
+			s.b = s.b[:len(s.b)-3]
+		}
+		n := len(s.b)
+		switch {
+		case s.ends("at"), s.ends("bl"), s.ends("iz"):
+			s.b = append(s.b, 'e')
+		case s.doubleCons(n):
+			if last := s.b[n-1]; last != 'l' && last != 's' && last != 'z' {
+				s.b = s.b[:n-1]
+			}
+		case s.measure(n) == 1 && s.cvc(n):
+			s.b = append(s.b, 'e')
+		}
+	}
+
+	if s.ends("y") && s.hasVowel(len(s.b)-1) {
+		s.b[len(s.b)-1] = 'i'
+	}
+}
+
+var step2Rules = []stemRule{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"abli", "able"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+}
+
+func (s *stemmer) step2() {
+	s.replace(step2Rules, 0)
+}
+
+var step3Rules = []stemRule{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+func (s *stemmer) step3() {
+	s.replace(step3Rules, 0)
+}
+
+var step4Rules = []stemRule{
+	{"al", ""}, {"ance", ""}, {"ence", ""}, {"er", ""}, {"ic", ""}, {"able", ""},
+	{"ible", ""}, {"ant", ""}, {"ement", ""}, {"ment", ""}, {"ent", ""},
+	{"ou", ""}, {"ism", ""}, {"ate", ""}, {"iti", ""}, {"ous", ""}, {"ive", ""},
+	{"ize", ""},
+}
+
+func (s *stemmer) step4() {
+	// "ion" is only removed after s or t, as in "adoption" but not "onion"
+	if s.ends("ion") {
+		n := len(s.b) - 3
+		if n > 0 && (s.b[n-1] == 's' || s.b[n-1] == 't') && s.measure(n) > 1 {
+			s.b = s.b[:n]
+		}
+		return
+	}
+	s.replace(step4Rules, 1)
+}
+
+func (s *stemmer) step5() {
+	if s.ends("e") {
+		n := len(s.b) - 1
+		if m := s.measure(n); m > 1 || (m == 1 && !s.cvc(n)) {
+			s.b = s.b[:n]
+		}
+	}
+	n := len(s.b)
+	if s.b[n-1] == 'l' && s.doubleCons(n) && s.measure(n) > 1 {
+		s.b = s.b[:n-1]
+	}
+}
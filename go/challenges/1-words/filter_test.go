@@ -0,0 +1,124 @@
+package words
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestCountWordsWithOptions(t *testing.T) {
+	text := "The runner was running, and she runs. The END!"
+	opts := Options{
+		Filters: []TokenFilter{
+			NewStopwordFilter(EnglishStopwords...),
+			PorterStemmer{},
+		},
+	}
+	expected := map[string]int{"runner": 1, "run": 2, "end": 1}
+
+	result := CountWordsWithOptions(text, opts)
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCountWordsWithOptionsTokenizer(t *testing.T) {
+	opts := Options{Tokenizer: &Tokenizer{CaseSensitive: true}}
+	expected := map[string]int{"Go": 1, "go": 1}
+
+	result := CountWordsWithOptions("Go go", opts)
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestStopwordFilterCaseSensitive(t *testing.T) {
+	opts := Options{
+		Tokenizer: &Tokenizer{CaseSensitive: true},
+		Filters:   []TokenFilter{NewStopwordFilter(EnglishStopwords...)},
+	}
+	expected := map[string]int{"Go": 1, "go": 1}
+	if result := CountWordsWithOptions("The Go and the go", opts); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	// Language stopwords ignore case too
+	opts = Options{Tokenizer: &Tokenizer{CaseSensitive: true}, Language: "fr"}
+	expected = map[string]int{"Homme": 1, "homme": 1}
+	if result := CountWordsWithOptions("Le Homme et le homme", opts); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCountReaderWithOptions(t *testing.T) {
+	text := "The runner was running, and she runs. The END!"
+	opts := Options{Filters: []TokenFilter{NewStopwordFilter(EnglishStopwords...), PorterStemmer{}}}
+
+	result, err := CountReaderWithOptions(strings.NewReader(text), opts)
+	if err != nil {
+		t.Fatalf("CountReaderWithOptions returned error: %v", err)
+	}
+	if expected := CountWordsWithOptions(text, opts); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestLengthFilter(t *testing.T) {
+	opts := Options{Filters: []TokenFilter{LengthFilter{Min: 2, Max: 4}}}
+	expected := map[string]int{"go": 1, "über": 1, "fun": 1}
+
+	result := CountWordsWithOptions("a go über fun runtime", opts)
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestFilterFunc(t *testing.T) {
+	// Map irregular forms the stemmer can't handle
+	irregular := FilterFunc(func(token string) (string, bool) {
+		if token == "ran" {
+			return "run", true
+		}
+		return token, true
+	})
+	opts := Options{Filters: []TokenFilter{irregular, PorterStemmer{}}}
+
+	result := CountWordsWithOptions("ran running runs", opts)
+	if result["run"] != 3 {
+		t.Errorf("Expected run=3, got %v", result)
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress",
+		"cats": "cat", "feed": "feed", "agreed": "agre", "plastered": "plaster",
+		"motoring": "motor", "sing": "sing", "conflated": "conflat", "troubled": "troubl",
+		"sized": "size", "hopping": "hop", "tanned": "tan", "falling": "fall",
+		"hissing": "hiss", "fizzed": "fizz", "failing": "fail", "filing": "file",
+		"happy": "happi", "sky": "sky", "relational": "relat", "conditional": "condit",
+		"rational": "ration", "valenci": "valenc", "digitizer": "digit", "conformabli": "conform",
+		"radicalli": "radic", "differentli": "differ", "vileli": "vile", "analogousli": "analog",
+		"vietnamization": "vietnam", "predication": "predic", "operator": "oper",
+		"feudalism": "feudal", "decisiveness": "decis", "hopefulness": "hope",
+		"callousness": "callous", "formaliti": "formal", "sensitiviti": "sensit",
+		"sensibiliti": "sensibl", "triplicate": "triplic", "formative": "form",
+		"formalize": "formal", "electriciti": "electr", "electrical": "electr",
+		"hopeful": "hope", "goodness": "good", "revival": "reviv", "allowance": "allow",
+		"inference": "infer", "airliner": "airlin", "gyroscopic": "gyroscop",
+		"adjustable": "adjust", "defensible": "defens", "irritant": "irrit",
+		"replacement": "replac", "adjustment": "adjust", "dependent": "depend",
+		"adoption": "adopt", "homologou": "homolog", "communism": "commun",
+		"activate": "activ", "angulariti": "angular", "homologous": "homolog",
+		"effective": "effect", "bowdlerize": "bowdler", "probate": "probat",
+		"rate": "rate", "cease": "ceas", "controll": "control", "roll": "roll",
+		"running": "run", "runs": "run", "connection": "connect", "generalization": "gener",
+		"a": "a", "is": "is", "don't": "don't", "naïve": "naïve",
+	}
+
+	for word, expected := range tests {
+		if result := Stem(word); result != expected {
+			t.Errorf("Stem(%q): expected %q, got %q", word, expected, result)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package words
+
+import (
+	"container/heap"
+	"io"
+)
+
+type HeavyHitter struct {
+	WordCount     // Count overestimates the true count by at most Error
+	Error     int // The true count is within [Count-Error, Count]
+}
+
+// HeavyHitters tracks the most frequent words of a stream in fixed memory
+// using the Space-Saving algorithm. Every word seen more than Total/capacity
+// times is guaranteed to be tracked.
+type HeavyHitters struct {
+	capacity  int
+	total     int
+	counters  hitterHeap
+	index     map[string]*hitterEntry
+	tokenizer *Tokenizer
+}
+
+type hitterEntry struct {
+	HeavyHitter
+	position int
+}
+
+func NewHeavyHitters(capacity int) *HeavyHitters {
+	return &HeavyHitters{
+		capacity:  max(capacity, 1),
+		index:     make(map[string]*hitterEntry, capacity),
+		tokenizer: defaultTokenizer,
+	}
+}
+
+func (h *HeavyHitters) Add(word string) {
+	h.total += 1
+	if e, found := h.index[word]; found {
+		e.Count += 1
+		heap.Fix(&h.counters, e.position)
+		return
+	}
+	if len(h.counters) < h.capacity {
+		e := &hitterEntry{HeavyHitter: HeavyHitter{WordCount: WordCount{Word: word, Count: 1}}}
+		heap.Push(&h.counters, e)
+		h.index[word] = e
+		return
+	}
+	// Take over the smallest counter; the new word may have been counted there
+	e := h.counters[0]
+	delete(h.index, e.Word)
+	e.Error = e.Count
+	e.Word = word
+	e.Count += 1
+	h.index[word] = e
+	heap.Fix(&h.counters, 0)
+}
+
+func (h *HeavyHitters) AddText(text string) {
+	h.tokenizer.each([]byte(text), h.Add)
+}
+
+func (h *HeavyHitters) AddReader(r io.Reader) error {
+	scanner := h.tokenizer.newScanner(r)
+	for scanner.Scan() {
+		h.Add(h.tokenizer.Normalize(scanner.Text()))
+	}
+	return scanner.Err()
+}
+
+// Total returns the number of words added so far.
+func (h *HeavyHitters) Total() int {
+	return h.total
+}
+
+func (h *HeavyHitters) Top(n int) []HeavyHitter {
+	frequencies := make(map[string]int, len(h.index))
+	for word, e := range h.index {
+		frequencies[word] = e.Count
+	}
+	top := TopWords(frequencies, n)
+	result := make([]HeavyHitter, len(top))
+	for i, wc := range top {
+		result[i] = HeavyHitter{WordCount: wc, Error: h.index[wc.Word].Error}
+	}
+	return result
+}
+
+// hitterHeap is a min-heap on Count
+type hitterHeap []*hitterEntry
+
+func (hh hitterHeap) Len() int { return len(hh) }
+
+func (hh hitterHeap) Less(i, j int) bool { return hh[i].Count < hh[j].Count }
+
+func (hh hitterHeap) Swap(i, j int) {
+	hh[i], hh[j] = hh[j], hh[i]
+	hh[i].position = i
+	hh[j].position = j
+}
+
+func (hh *hitterHeap) Push(x any) {
+	e := x.(*hitterEntry)
+	e.position = len(*hh)
+	*hh = append(*hh, e)
+}
+
+func (hh *hitterHeap) Pop() any {
+	old := *hh
+	e := old[len(old)-1]
+	*hh = old[:len(old)-1]
+	return e
+}
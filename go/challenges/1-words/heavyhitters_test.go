@@ -0,0 +1,78 @@
+package words
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestHeavyHittersExact(t *testing.T) {
+	hh := NewHeavyHitters(10)
+	hh.AddText("Hello world! Hello Go programming. Go is great, Go is powerful.")
+
+	result := hh.Top(3)
+	expected := []HeavyHitter{
+		{WordCount{"go", 3}, 0},
+		{WordCount{"hello", 2}, 0},
+		{WordCount{"is", 2}, 0},
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d results, got %v", len(expected), result)
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("Expected %v at %d, got %v", expected[i], i, result[i])
+		}
+	}
+}
+
+func TestHeavyHittersStream(t *testing.T) {
+	hh := NewHeavyHitters(20)
+	exact := map[string]int{}
+
+	// Zipf-distributed stream with far more distinct words than counters
+	rng := rand.New(rand.NewSource(1))
+	zipf := rand.NewZipf(rng, 1.2, 1, 5000)
+	for range 100_000 {
+		word := fmt.Sprintf("w%d", zipf.Uint64())
+		hh.Add(word)
+		exact[word] += 1
+	}
+
+	if hh.Total() != 100_000 {
+		t.Errorf("Expected total 100000, got %d", hh.Total())
+	}
+
+	expected := TopWords(exact, 3)
+	result := hh.Top(3)
+	for i, top := range expected {
+		if result[i].Word != top.Word {
+			t.Errorf("Expected %q at %d, got %q", top.Word, i, result[i].Word)
+		}
+	}
+
+	for _, hitter := range hh.Top(20) {
+		real := exact[hitter.Word]
+		if real > hitter.Count || real < hitter.Count-hitter.Error {
+			t.Errorf("%q: true count %d outside [%d, %d]", hitter.Word, real, hitter.Count-hitter.Error, hitter.Count)
+		}
+		if hitter.Error > hh.Total()/20 {
+			t.Errorf("%q: error %d exceeds total/capacity", hitter.Word, hitter.Error)
+		}
+	}
+}
+
+func TestHeavyHittersFixedMemory(t *testing.T) {
+	hh := NewHeavyHitters(5)
+	err := hh.AddReader(strings.NewReader("a b c d e f g h i j k l m n o p a a a"))
+	if err != nil {
+		t.Fatalf("AddReader returned error: %v", err)
+	}
+	if len(hh.Top(100)) != 5 {
+		t.Errorf("Expected at most 5 tracked words, got %d", len(hh.Top(100)))
+	}
+	if top := hh.Top(1); top[0].Word != "a" {
+		t.Errorf("Expected 'a' to be the top word, got %v", top)
+	}
+}
@@ -0,0 +1,551 @@
+package words
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+const indexFormatVersion = 1
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+type Posting struct {
+	Doc       int   // Position of the document in the index
+	Positions []int // Token offsets of the term within the document
+}
+
+type SearchResult struct {
+	ID    string
+	Score float64
+}
+
+// Index is an inverted index from terms to the documents and positions
+// they occur at. Documents are tokenized with the same Options as queries.
+// With AutoDetect, a document's language is detected once when it's added,
+// and query terms are matched against it with that language's rules.
+type Index struct {
+	opts        Options
+	docs        []indexDoc
+	ids         map[string]int
+	postings    map[string][]Posting
+	totalLength int
+	languages   []string // Sorted languages of the documents
+}
+
+type indexDoc struct {
+	ID       string
+	Length   int
+	Language string // Detected with AutoDetect, empty if uncertain or unused
+}
+
+// indexFile is the on-disk layout written by Save
+type indexFile struct {
+	Version  int
+	Docs     []indexDoc
+	Postings map[string][]Posting
+}
+
+func NewIndex(opts Options) *Index {
+	return &Index{
+		opts:     opts,
+		ids:      map[string]int{},
+		postings: map[string][]Posting{},
+	}
+}
+
+func (idx *Index) Add(id, text string) error {
+	if _, found := idx.ids[id]; found {
+		return fmt.Errorf("document %q already indexed", id)
+	}
+	doc := len(idx.docs)
+	code := ""
+	if idx.opts.Language == AutoDetect {
+		sample := text
+		if idx.opts.Markup != 0 {
+			sample = CleanMarkup(text, idx.opts.Markup)
+		}
+		code = detectCode(sample)
+	}
+	positions := map[string][]int{}
+	length := 0
+	idx.rules(code).each([]byte(text), func(token string) {
+		positions[token] = append(positions[token], length)
+		length++
+	})
+	// Documents are only appended, so posting lists stay sorted by Doc
+	for term, pos := range positions {
+		idx.postings[term] = append(idx.postings[term], Posting{Doc: doc, Positions: pos})
+	}
+	idx.ids[id] = doc
+	idx.docs = append(idx.docs, indexDoc{ID: id, Length: length, Language: code})
+	idx.totalLength += length
+	idx.addLanguage(code)
+	return nil
+}
+
+// rules returns the options for documents in language code, which is only
+// set with AutoDetect.
+func (idx *Index) rules(code string) Options {
+	opts := idx.opts
+	if opts.Language == AutoDetect {
+		opts.Language = code
+	}
+	return opts
+}
+
+func (idx *Index) addLanguage(code string) {
+	if i, found := slices.BinarySearch(idx.languages, code); !found {
+		idx.languages = slices.Insert(idx.languages, i, code)
+	}
+}
+
+// tokens returns text as tokenized for documents in language code
+func (idx *Index) tokens(code, text string) []string {
+	tokens := []string{}
+	idx.rules(code).each([]byte(text), func(token string) {
+		tokens = append(tokens, token)
+	})
+	return tokens
+}
+
+func (idx *Index) Len() int {
+	return len(idx.docs)
+}
+
+// Search evaluates a boolean query and returns matching document IDs in the
+// order they were added. Terms are joined by AND, OR and NOT, grouped with
+// parentheses, and "quoted text" matches an exact phrase. Adjacent terms
+// without an operator are ANDed.
+func (idx *Index) Search(query string) ([]string, error) {
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	docs, _ := idx.eval(q)
+	return idx.docIDs(docs), nil
+}
+
+// Rank returns the n best documents matching query, scored with BM25 over
+// the query's terms that aren't negated.
+func (idx *Index) Rank(query string, n int) ([]SearchResult, error) {
+	q, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	docs, _ := idx.eval(q)
+	terms := map[string][]string{}
+	for _, code := range idx.languages {
+		for _, word := range q.terms(false) {
+			terms[code] = append(terms[code], idx.tokens(code, word)...)
+		}
+	}
+
+	results := make([]SearchResult, 0, len(docs))
+	for _, doc := range docs {
+		score := idx.bm25(doc, terms[idx.docs[doc].Language])
+		results = append(results, SearchResult{ID: idx.docs[doc].ID, Score: score})
+	}
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	if len(results) > max(n, 0) {
+		results = results[:max(n, 0)]
+	}
+	return results, nil
+}
+
+func (idx *Index) bm25(doc int, terms []string) float64 {
+	n := float64(len(idx.docs))
+	avgLength := float64(idx.totalLength) / n
+	length := float64(idx.docs[doc].Length)
+	score := 0.0
+	for _, term := range terms {
+		postings := idx.postings[term]
+		posting, found := findPosting(postings, doc)
+		if !found {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		tf := float64(len(posting.Positions))
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avgLength))
+	}
+	return score
+}
+
+// Save writes the index in a versioned gob encoding. Options aren't saved;
+// pass the same ones to LoadIndex.
+func (idx *Index) Save(w io.Writer) error {
+	file := indexFile{
+		Version:  indexFormatVersion,
+		Docs:     idx.docs,
+		Postings: idx.postings,
+	}
+	if err := gob.NewEncoder(w).Encode(file); err != nil {
+		return fmt.Errorf("save index: %v", err)
+	}
+	return nil
+}
+
+func LoadIndex(r io.Reader, opts Options) (*Index, error) {
+	var file indexFile
+	if err := gob.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("load index: %v", err)
+	}
+	if file.Version != indexFormatVersion {
+		return nil, fmt.Errorf("load index: unsupported version %d", file.Version)
+	}
+	idx := NewIndex(opts)
+	idx.docs = file.Docs
+	if file.Postings != nil {
+		idx.postings = file.Postings
+	}
+	for i, doc := range idx.docs {
+		if _, found := idx.ids[doc.ID]; found {
+			return nil, fmt.Errorf("load index: duplicate document %q", doc.ID)
+		}
+		idx.ids[doc.ID] = i
+		idx.totalLength += doc.Length
+		idx.addLanguage(doc.Language)
+	}
+	if err := idx.validatePostings(); err != nil {
+		return nil, fmt.Errorf("load index: %v", err)
+	}
+	return idx, nil
+}
+
+// validatePostings checks the invariants Add keeps, so a corrupt file
+// can't make searches index out of range.
+func (idx *Index) validatePostings() error {
+	for term, postings := range idx.postings {
+		for i, p := range postings {
+			if p.Doc < 0 || p.Doc >= len(idx.docs) {
+				return fmt.Errorf("term %q: document %d out of range", term, p.Doc)
+			}
+			if i > 0 && p.Doc <= postings[i-1].Doc {
+				return fmt.Errorf("term %q: postings not sorted by document", term)
+			}
+			for j := 1; j < len(p.Positions); j++ {
+				if p.Positions[j] <= p.Positions[j-1] {
+					return fmt.Errorf("term %q: positions not sorted in document %d", term, p.Doc)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (idx *Index) docIDs(docs []int) []string {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = idx.docs[doc].ID
+	}
+	return ids
+}
+
+// eval returns the sorted documents matching q. ok is false when q has no
+// indexable terms, such as a lone stopword, so it can be ignored.
+func (idx *Index) eval(q *queryNode) (docs []int, ok bool) {
+	switch q.op {
+	case queryAnd, queryOr:
+		left, lok := idx.eval(q.left)
+		right, rok := idx.eval(q.right)
+		switch {
+		case !lok:
+			return right, rok
+		case !rok:
+			return left, lok
+		case q.op == queryAnd:
+			return intersectDocs(left, right), true
+		default:
+			return unionDocs(left, right), true
+		}
+	case queryNot:
+		docs, ok := idx.eval(q.left)
+		if !ok {
+			return nil, false
+		}
+		all := make([]int, len(idx.docs))
+		for i := range all {
+			all[i] = i
+		}
+		return subtractDocs(all, docs), true
+	}
+
+	// Each language tokenizes the term its own way and matches only its
+	// documents
+	docs, ok = []int{}, false
+	for _, code := range idx.languages {
+		tokens := idx.tokens(code, q.text)
+		if len(tokens) == 0 {
+			continue
+		}
+		matched := slices.DeleteFunc(idx.phrase(tokens), func(doc int) bool {
+			return idx.docs[doc].Language != code
+		})
+		docs, ok = unionDocs(docs, matched), true
+	}
+	return docs, ok
+}
+
+// phrase returns the documents where tokens appear consecutively
+func (idx *Index) phrase(tokens []string) []int {
+	docs := []int{}
+	for _, first := range idx.postings[tokens[0]] {
+		for _, start := range first.Positions {
+			if idx.phraseAt(first.Doc, start, tokens[1:]) {
+				docs = append(docs, first.Doc)
+				break
+			}
+		}
+	}
+	return docs
+}
+
+func (idx *Index) phraseAt(doc, start int, rest []string) bool {
+	for i, token := range rest {
+		posting, found := findPosting(idx.postings[token], doc)
+		if !found {
+			return false
+		}
+		if _, found := slices.BinarySearch(posting.Positions, start+i+1); !found {
+			return false
+		}
+	}
+	return true
+}
+
+func findPosting(postings []Posting, doc int) (Posting, bool) {
+	i, found := slices.BinarySearchFunc(postings, doc, func(p Posting, doc int) int {
+		return p.Doc - doc
+	})
+	if !found {
+		return Posting{}, false
+	}
+	return postings[i], true
+}
+
+func intersectDocs(a, b []int) []int {
+	result := []int{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func unionDocs(a, b []int) []int {
+	result := []int{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			result = append(result, a[i])
+			i++
+		case a[i] > b[j]:
+			result = append(result, b[j])
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
+
+func subtractDocs(a, b []int) []int {
+	result := []int{}
+	j := 0
+	for _, doc := range a {
+		for j < len(b) && b[j] < doc {
+			j++
+		}
+		if j == len(b) || b[j] != doc {
+			result = append(result, doc)
+		}
+	}
+	return result
+}
+
+type queryOp int
+
+const (
+	queryTerm queryOp = iota // A word or a quoted phrase
+	queryAnd
+	queryOr
+	queryNot
+)
+
+type queryNode struct {
+	op          queryOp
+	text        string
+	left, right *queryNode
+}
+
+// terms returns the text of the query's terms, skipping negated ones
+func (q *queryNode) terms(negated bool) []string {
+	switch q.op {
+	case queryTerm:
+		if negated {
+			return nil
+		}
+		return []string{q.text}
+	case queryNot:
+		return q.left.terms(!negated)
+	}
+	return append(q.left.terms(negated), q.right.terms(negated)...)
+}
+
+// queryParser is a recursive descent parser for
+//
+//	or    = and { "OR" and }
+//	and   = unary { ["AND"] unary }
+//	unary = "NOT" unary | "(" or ")" | term
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func parseQuery(query string) (*queryNode, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("parse query: empty query")
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %v", err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("parse query: unexpected %q", p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+func lexQuery(query string) ([]string, error) {
+	tokens := []string{}
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			end := strings.IndexByte(query[i+1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("parse query: unterminated phrase")
+			}
+			tokens = append(tokens, query[i:i+end+2])
+			i += end + 2
+		default:
+			end := i
+			for end < len(query) && !strings.ContainsRune(" \t\n()\"", rune(query[end])) {
+				end++
+			}
+			tokens = append(tokens, query[i:end])
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: queryOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case "", ")", "OR":
+			return left, nil
+		case "AND":
+			p.pos++
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &queryNode{op: queryAnd, left: left, right: right}
+	}
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	token := p.peek()
+	switch token {
+	case "":
+		return nil, fmt.Errorf("unexpected end of query")
+	case "AND", "OR", ")":
+		return nil, fmt.Errorf("unexpected %q", token)
+	case "NOT":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{op: queryNot, left: operand}, nil
+	case "(":
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	}
+	p.pos++
+	return &queryNode{op: queryTerm, text: strings.Trim(token, `"`)}, nil
+}
@@ -0,0 +1,186 @@
+package words
+
+import (
+	"bytes"
+	"encoding/gob"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newTestIndex(t *testing.T) *Index {
+	t.Helper()
+	idx := NewIndex(Options{})
+	docs := []struct{ id, text string }{
+		{"pool", "The worker pool runs jobs on goroutines. A pool of workers reads jobs."},
+		{"limiter", "The rate limiter uses a token bucket per key."},
+		{"fetcher", "The fetcher uses a worker pool to fetch pages."},
+		{"cache", "The LRU cache evicts the least recently used key."},
+	}
+	for _, d := range docs {
+		if err := idx.Add(d.id, d.text); err != nil {
+			t.Fatalf("Add(%q) returned error: %v", d.id, err)
+		}
+	}
+	return idx
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := newTestIndex(t)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"worker", []string{"pool", "fetcher"}},
+		{"Worker AND pool", []string{"pool", "fetcher"}},
+		{"worker pool", []string{"pool", "fetcher"}},
+		{"limiter OR cache", []string{"limiter", "cache"}},
+		{"pool NOT fetcher", []string{"pool"}},
+		{"key AND NOT (cache OR fetcher)", []string{"limiter"}},
+		{"NOT the", []string{}},
+		{`"worker pool"`, []string{"pool", "fetcher"}},
+		{`"pool worker"`, []string{}},
+		{`"pool of workers" OR bucket`, []string{"pool", "limiter"}},
+		{"missing", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			result, err := idx.Search(tt.query)
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIndexSearchErrors(t *testing.T) {
+	idx := newTestIndex(t)
+	for _, query := range []string{"(worker", "worker)", `"worker`, "worker AND", "OR pool", ""} {
+		if _, err := idx.Search(query); err == nil {
+			t.Errorf("Expected error for query %q", query)
+		}
+	}
+	if err := idx.Add("pool", "duplicate"); err == nil {
+		t.Error("Expected error when adding a duplicate document")
+	}
+}
+
+func TestIndexRank(t *testing.T) {
+	idx := newTestIndex(t)
+
+	result, err := idx.Rank("pool OR jobs", 10)
+	if err != nil {
+		t.Fatalf("Rank returned error: %v", err)
+	}
+	ids := []string{}
+	for _, r := range result {
+		ids = append(ids, r.ID)
+	}
+	// "pool" mentions both terms more often than "fetcher"
+	if !slices.Equal(ids, []string{"pool", "fetcher"}) {
+		t.Errorf("Expected [pool fetcher], got %v", result)
+	}
+	if result[0].Score <= result[1].Score {
+		t.Errorf("Expected descending scores, got %v", result)
+	}
+
+	top, _ := idx.Rank("pool OR jobs", 1)
+	if len(top) != 1 || top[0].ID != "pool" {
+		t.Errorf("Expected only 'pool', got %v", top)
+	}
+}
+
+// Queries are too short to detect, so they must take the rules of the
+// language each document was indexed with.
+func TestIndexAutoDetect(t *testing.T) {
+	idx := NewIndex(Options{Language: AutoDetect})
+	idx.Add("fr", "L'homme regarde l'horizon pendant que la femme lit le journal avec les enfants.")
+	idx.Add("en", "The man watches the horizon while the woman reads the paper with the children.")
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"l'homme", []string{"fr"}},
+		{`"l'homme regarde"`, []string{"fr"}},
+		{"horizon", []string{"fr", "en"}},
+		{"the OR le", []string{}},
+		{"horizon NOT journal", []string{"en"}},
+	}
+	for _, tt := range tests {
+		result, err := idx.Search(tt.query)
+		if err != nil {
+			t.Fatalf("%q: Search returned error: %v", tt.query, err)
+		}
+		if !slices.Equal(result, tt.expected) {
+			t.Errorf("%q: expected %v, got %v", tt.query, tt.expected, result)
+		}
+	}
+
+	var buf bytes.Buffer
+	idx.Save(&buf)
+	loaded, err := LoadIndex(&buf, Options{Language: AutoDetect})
+	if err != nil {
+		t.Fatalf("LoadIndex returned error: %v", err)
+	}
+	if result, _ := loaded.Rank("l'homme", 5); len(result) != 1 || result[0].ID != "fr" || result[0].Score <= 0 {
+		t.Errorf("Expected fr to rank after load, got %v", result)
+	}
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	idx := newTestIndex(t)
+
+	var buf bytes.Buffer
+	if err := idx.Save(&buf); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	loaded, err := LoadIndex(&buf, Options{})
+	if err != nil {
+		t.Fatalf("LoadIndex returned error: %v", err)
+	}
+
+	for _, query := range []string{"worker AND pool", `"token bucket"`, "key NOT cache"} {
+		expected, _ := idx.Search(query)
+		result, _ := loaded.Search(query)
+		if !slices.Equal(result, expected) {
+			t.Errorf("%q: expected %v after load, got %v", query, expected, result)
+		}
+	}
+	r1, _ := idx.Rank("worker pool", 5)
+	r2, _ := loaded.Rank("worker pool", 5)
+	if !slices.Equal(r1, r2) {
+		t.Errorf("Expected ranking %v after load, got %v", r1, r2)
+	}
+
+	if _, err := LoadIndex(strings.NewReader("garbage"), Options{}); err == nil {
+		t.Error("Expected error loading garbage")
+	}
+}
+
+func TestLoadIndexCorrupt(t *testing.T) {
+	docs := []indexDoc{{ID: "a", Length: 3}, {ID: "b", Length: 3}}
+	tests := map[string]indexFile{
+		"doc out of range":   {Docs: docs[:1], Postings: map[string][]Posting{"go": {{Doc: 5, Positions: []int{0}}}}},
+		"negative doc":       {Docs: docs, Postings: map[string][]Posting{"go": {{Doc: -1, Positions: []int{0}}}}},
+		"unsorted docs":      {Docs: docs, Postings: map[string][]Posting{"go": {{Doc: 1}, {Doc: 0}}}},
+		"repeated doc":       {Docs: docs, Postings: map[string][]Posting{"go": {{Doc: 0}, {Doc: 0}}}},
+		"unsorted positions": {Docs: docs, Postings: map[string][]Posting{"go": {{Doc: 0, Positions: []int{2, 1}}}}},
+		"duplicate id":       {Docs: []indexDoc{{ID: "a"}, {ID: "a"}}},
+	}
+	for name, file := range tests {
+		file.Version = indexFormatVersion
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(file); err != nil {
+			t.Fatalf("%s: encode returned error: %v", name, err)
+		}
+		if _, err := LoadIndex(&buf, Options{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
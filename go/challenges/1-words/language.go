@@ -0,0 +1,346 @@
+package words
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AutoDetect as Options.Language picks the language with DetectLanguage.
+const AutoDetect = "auto"
+
+const (
+	detectSampleSize = 4096    // Bytes of a stream read to detect its language
+	detectEvidence   = 16      // Letters at which a guess is trusted halfway
+	profileVocabSize = 1 << 16 // Assumed n-gram vocabulary for smoothing
+	profileSmoothing = 0.01    // Count added to every n-gram, seen or not
+
+	// AutoDetect applies no language rules to text whose best guess is less
+	// certain than this, which rules out single words and short phrases.
+	minDetectConfidence = 0.6
+)
+
+// Language holds the counting rules for one language. Its profile of
+// character n-grams is built from the sample text it's registered with.
+type Language struct {
+	Code      string     // ISO 639-1 code, e.g. "fr"
+	Name      string     // English name, e.g. "French"
+	Tokenizer *Tokenizer // nil means the default tokenizer of CountWords
+	Stopwords []string   // Normalized tokens dropped when counting
+
+	stopwords StopwordFilter
+	profile   map[string]float64 // Log probability of each n-gram
+	unseen    float64            // Log probability of an n-gram not in profile
+}
+
+type LanguageGuess struct {
+	Language   string  // ISO 639-1 code
+	Confidence float64 // Probability among the registered languages
+}
+
+//go:embed languages/*.txt
+var languageSamples embed.FS
+
+var (
+	languagesMu sync.RWMutex
+	languages   = map[string]*Language{}
+)
+
+func init() {
+	elision := &Tokenizer{} // Splits "l'homme" into "l" and "homme"
+	builtin := []Language{
+		{Code: "en", Name: "English", Stopwords: EnglishStopwords},
+		{Code: "de", Name: "German", Stopwords: germanStopwords},
+		{Code: "fr", Name: "French", Tokenizer: elision, Stopwords: frenchStopwords},
+		{Code: "es", Name: "Spanish", Stopwords: spanishStopwords},
+		{Code: "it", Name: "Italian", Tokenizer: elision, Stopwords: italianStopwords},
+		{Code: "pt", Name: "Portuguese", Tokenizer: &Tokenizer{KeepHyphenated: true}, Stopwords: portugueseStopwords},
+		{Code: "nl", Name: "Dutch", Stopwords: dutchStopwords},
+		{Code: "sv", Name: "Swedish", Stopwords: swedishStopwords},
+		{Code: "pl", Name: "Polish", Stopwords: polishStopwords},
+		{Code: "tr", Name: "Turkish", Stopwords: turkishStopwords},
+		{Code: "ru", Name: "Russian", Stopwords: russianStopwords},
+		{Code: "fi", Name: "Finnish", Stopwords: finnishStopwords},
+	}
+	for _, lang := range builtin {
+		sample, err := languageSamples.ReadFile("languages/" + lang.Code + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		if err := RegisterLanguage(lang, string(sample)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterLanguage adds lang, or replaces the language with the same code,
+// building its profile from sample. Short samples give unreliable
+// profiles; the built-in ones are about 2 KB of ordinary prose.
+func RegisterLanguage(lang Language, sample string) error {
+	if lang.Code == "" || lang.Code == AutoDetect {
+		return fmt.Errorf("register language: invalid code %q", lang.Code)
+	}
+	counts := map[string]int{}
+	total := 0
+	eachGram(sample, func(gram string) {
+		counts[gram]++
+		total++
+	})
+	if total == 0 {
+		return fmt.Errorf("register language %s: sample has no letters", lang.Code)
+	}
+
+	denominator := math.Log(float64(total) + profileSmoothing*profileVocabSize)
+	lang.profile = make(map[string]float64, len(counts))
+	for gram, count := range counts {
+		lang.profile[gram] = math.Log(float64(count)+profileSmoothing) - denominator
+	}
+	lang.unseen = math.Log(profileSmoothing) - denominator
+	lang.stopwords = NewStopwordFilter(lang.Stopwords...)
+
+	languagesMu.Lock()
+	languages[lang.Code] = &lang
+	languagesMu.Unlock()
+	return nil
+}
+
+func LookupLanguage(code string) (*Language, bool) {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+	lang, found := languages[code]
+	return lang, found
+}
+
+// DetectLanguage ranks the registered languages by how likely they are to
+// have produced text, comparing its character n-grams with each profile.
+// The n-grams overlap, so their likelihoods overstate the evidence in short
+// text; confidence is blended with a uniform guess by letter count to keep
+// a few letters from looking certain. It returns nil if text has no
+// letters.
+func DetectLanguage(text string) []LanguageGuess {
+	grams := map[string]int{}
+	eachGram(text, func(gram string) {
+		grams[gram]++
+	})
+	if len(grams) == 0 {
+		return nil
+	}
+	letters := 0
+	for gram, count := range grams {
+		if utf8.RuneCountInString(gram) == 1 {
+			letters += count
+		}
+	}
+
+	languagesMu.RLock()
+	result := make([]LanguageGuess, 0, len(languages))
+	for code, lang := range languages {
+		score := 0.0
+		for gram, count := range grams {
+			p, found := lang.profile[gram]
+			if !found {
+				p = lang.unseen
+			}
+			score += float64(count) * p
+		}
+		result = append(result, LanguageGuess{Language: code, Confidence: score})
+	}
+	languagesMu.RUnlock()
+
+	// Turn log likelihoods into probabilities, shifting by the best one so
+	// the exponentials don't underflow
+	best := slices.MaxFunc(result, func(a, b LanguageGuess) int {
+		return compareFloat(a.Confidence, b.Confidence)
+	}).Confidence
+	sum := 0.0
+	for i := range result {
+		result[i].Confidence = math.Exp(result[i].Confidence - best)
+		sum += result[i].Confidence
+	}
+	weight := float64(letters) / float64(letters+detectEvidence)
+	for i := range result {
+		result[i].Confidence = weight*result[i].Confidence/sum + (1-weight)/float64(len(result))
+	}
+
+	slices.SortFunc(result, func(a, b LanguageGuess) int {
+		if c := compareFloat(b.Confidence, a.Confidence); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return result
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// eachGram calls fn with every run of one to three runes in the
+// lower-cased words of text, padded with a space on each side so word
+// starts and ends are part of the profile.
+func eachGram(text string, fn func(gram string)) {
+	word := []rune{' '}
+	flush := func() {
+		if len(word) > 1 {
+			word = append(word, ' ')
+			for n := 1; n <= 3; n++ {
+				for i := 0; i+n <= len(word); i++ {
+					if n > 1 || word[i] != ' ' {
+						fn(string(word[i : i+n]))
+					}
+				}
+			}
+		}
+		word = word[:1]
+	}
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			word = append(word, unicode.ToLower(r))
+		} else {
+			flush()
+		}
+	}
+	flush()
+}
+
+// Validate reports an error if Language is neither empty, AutoDetect nor
+// a registered code. CountReaderWithOptions checks this; functions that
+// can't return an error count with no language rules instead.
+func (o Options) Validate() error {
+	if o.Language == "" || o.Language == AutoDetect {
+		return nil
+	}
+	if _, found := LookupLanguage(o.Language); !found {
+		return fmt.Errorf("unknown language %q", o.Language)
+	}
+	return nil
+}
+
+// language returns the language selected by o for data, or nil.
+func (o Options) language(data []byte) *Language {
+	if o.Language == "" {
+		return nil
+	}
+	code := o.Language
+	if code == AutoDetect {
+		code = detectCode(string(data))
+	}
+	lang, _ := LookupLanguage(code)
+	return lang
+}
+
+// detectCode returns the language AutoDetect picks for text, or "" if the
+// best guess is too uncertain.
+func detectCode(text string) string {
+	guesses := DetectLanguage(text)
+	if len(guesses) == 0 || guesses[0].Confidence < minDetectConfidence {
+		return ""
+	}
+	return guesses[0].Language
+}
+
+// withLanguage returns o with the rules of lang applied: its tokenizer
+// unless o has one, and its stopwords ahead of the other filters.
+func (o Options) withLanguage(lang *Language) Options {
+	o.Language = ""
+	if lang == nil {
+		return o
+	}
+	if o.Tokenizer == nil {
+		o.Tokenizer = lang.Tokenizer
+	}
+	o.Filters = append([]TokenFilter{lang.stopwords}, o.Filters...)
+	return o
+}
+
+var germanStopwords = []string{
+	"aber", "alle", "als", "am", "an", "auch", "auf", "aus", "bei", "bin", "bis",
+	"da", "damit", "dann", "das", "dass", "dem", "den", "der", "des", "die", "doch",
+	"du", "durch", "ein", "eine", "einem", "einen", "einer", "er", "es", "für", "hat",
+	"ich", "ihr", "im", "in", "ist", "ja", "kann", "mit", "nach", "nicht", "noch",
+	"nur", "ob", "oder", "sie", "sind", "so", "über", "um", "und", "uns", "von",
+	"vor", "war", "was", "weil", "wenn", "wie", "wir", "wird", "zu", "zum", "zur",
+}
+
+var frenchStopwords = []string{
+	"à", "au", "aux", "avec", "c", "ce", "ces", "d", "dans", "de", "des", "du",
+	"elle", "en", "est", "et", "eux", "il", "ils", "j", "je", "l", "la", "le", "les",
+	"leur", "lui", "m", "ma", "mais", "me", "mes", "moi", "mon", "n", "ne", "nous",
+	"on", "ou", "par", "pas", "pour", "qu", "que", "qui", "s", "sa", "se", "ses",
+	"son", "sont", "sur", "t", "ta", "te", "tu", "un", "une", "vous", "y",
+}
+
+var spanishStopwords = []string{
+	"a", "al", "algo", "como", "con", "de", "del", "el", "ella", "en", "entre",
+	"es", "esta", "este", "fue", "ha", "la", "las", "le", "lo", "los", "más", "me",
+	"mi", "muy", "no", "nos", "o", "para", "pero", "por", "que", "se", "si", "sin",
+	"sobre", "su", "sus", "también", "te", "todo", "tu", "un", "una", "y", "ya", "yo",
+}
+
+var italianStopwords = []string{
+	"a", "al", "alla", "anche", "c", "che", "chi", "con", "da", "dal", "dei",
+	"del", "della", "di", "e", "è", "gli", "ha", "i", "il", "in", "l", "la", "le",
+	"lei", "lo", "lui", "ma", "mi", "ne", "nel", "nella", "non", "o", "per", "più",
+	"quello", "questo", "se", "si", "sono", "su", "sua", "suo", "un", "una", "uno",
+}
+
+var portugueseStopwords = []string{
+	"a", "ao", "aos", "as", "com", "como", "da", "das", "de", "do", "dos", "e",
+	"é", "ela", "ele", "em", "era", "eu", "foi", "isso", "já", "mais", "mas", "me",
+	"muito", "na", "nas", "não", "no", "nos", "o", "os", "ou", "para", "pela",
+	"pelo", "por", "que", "se", "sem", "seu", "sua", "também", "um", "uma",
+}
+
+var dutchStopwords = []string{
+	"aan", "al", "als", "bij", "dan", "dat", "de", "die", "dit", "door", "een",
+	"en", "er", "had", "heb", "het", "hij", "hoe", "hun", "ik", "in", "is", "je",
+	"kan", "maar", "me", "met", "mij", "naar", "niet", "nog", "of", "om", "ook",
+	"op", "over", "te", "tot", "uit", "van", "voor", "was", "wat", "we", "wel",
+	"werd", "wij", "zal", "ze", "zich", "zij", "zijn", "zo",
+}
+
+var swedishStopwords = []string{
+	"alla", "att", "av", "de", "dem", "den", "det", "din", "du", "efter", "en",
+	"ett", "för", "från", "han", "har", "hon", "honom", "hur", "i", "inte", "jag",
+	"kan", "man", "med", "men", "mig", "min", "nu", "när", "och", "om", "på",
+	"sig", "sin", "sina", "ska", "som", "så", "till", "under", "upp", "ut", "var",
+	"vad", "vi", "vid", "är",
+}
+
+var polishStopwords = []string{
+	"a", "ale", "bo", "by", "być", "co", "czy", "do", "dla", "go", "i", "ich",
+	"jak", "jest", "jego", "jej", "już", "ma", "mi", "na", "nie", "o", "od", "on",
+	"ona", "oni", "po", "przez", "się", "są", "ta", "tak", "te", "tego", "to",
+	"tylko", "w", "we", "z", "za", "że", "żeby",
+}
+
+var turkishStopwords = []string{
+	"ama", "ancak", "bir", "biz", "bu", "çok", "da", "daha", "de", "değil", "diye",
+	"en", "gibi", "hem", "her", "için", "ile", "ise", "kadar", "ki", "mi", "mı",
+	"ne", "o", "olan", "olarak", "sen", "şey", "şu", "ve", "veya", "ya", "yani",
+}
+
+var russianStopwords = []string{
+	"а", "без", "бы", "был", "была", "было", "в", "вам", "вы", "да", "для", "до",
+	"его", "ее", "её", "если", "есть", "же", "за", "и", "из", "или", "им", "их",
+	"к", "как", "когда", "ли", "мы", "на", "не", "нет", "но", "о", "он", "она",
+	"они", "от", "по", "с", "так", "то", "только", "у", "уже", "что", "это", "я",
+}
+
+var finnishStopwords = []string{
+	"ei", "eikä", "en", "ja", "jo", "joka", "jos", "kanssa", "kuin", "kun", "me",
+	"mitä", "mutta", "myös", "ne", "niin", "nyt", "oli", "olla", "on", "ovat",
+	"se", "sen", "siitä", "sillä", "sinä", "te", "tai", "tämä", "vaan", "vain",
+	"he", "hän", "minä", "että",
+}
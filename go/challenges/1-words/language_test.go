@@ -0,0 +1,137 @@
+package words
+
+import (
+	"maps"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := map[string]string{
+		"en": "The quick brown fox jumps over the lazy dog while the farmer watches from his window.",
+		"de": "Der schnelle braune Fuchs springt über den faulen Hund, während der Bauer aus dem Fenster schaut.",
+		"fr": "Le renard brun rapide saute par-dessus le chien paresseux pendant que le fermier regarde par la fenêtre.",
+		"es": "El rápido zorro marrón salta sobre el perro perezoso mientras el granjero mira desde su ventana.",
+		"it": "La volpe marrone veloce salta sopra il cane pigro mentre il contadino guarda dalla sua finestra.",
+		"pt": "A rápida raposa castanha salta por cima do cão preguiçoso enquanto o agricultor olha da sua janela.",
+		"nl": "De snelle bruine vos springt over de luie hond terwijl de boer vanuit zijn raam toekijkt.",
+		"sv": "Den snabba bruna räven hoppar över den lata hunden medan bonden tittar från sitt fönster.",
+		"pl": "Szybki brązowy lis przeskakuje nad leniwym psem, a rolnik patrzy przez swoje okno.",
+		"tr": "Hızlı kahverengi tilki tembel köpeğin üzerinden atlarken çiftçi penceresinden izliyor.",
+		"ru": "Быстрая коричневая лиса прыгает через ленивую собаку, пока фермер смотрит из своего окна.",
+		"fi": "Nopea ruskea kettu hyppää laiskan koiran yli, kun maanviljelijä katselee ikkunastaan.",
+	}
+	for expected, text := range tests {
+		guesses := DetectLanguage(text)
+		if len(guesses) == 0 || guesses[0].Language != expected {
+			t.Errorf("Expected %s first, got %v", expected, guesses[:min(3, len(guesses))])
+			continue
+		}
+		sum := 0.0
+		for i, g := range guesses {
+			sum += g.Confidence
+			if i > 0 && g.Confidence > guesses[i-1].Confidence {
+				t.Errorf("%s: guesses not ranked by confidence: %v", expected, guesses)
+			}
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("%s: expected confidences to sum to 1, got %v", expected, sum)
+		}
+	}
+
+	if guesses := DetectLanguage("123 !!! ..."); guesses != nil {
+		t.Errorf("Expected no guesses without letters, got %v", guesses)
+	}
+}
+
+func TestDetectLanguageConfidence(t *testing.T) {
+	long := DetectLanguage("Nobody knows what happened to the letter she wrote to him last winter.")
+	if long[0].Language != "en" || long[0].Confidence < minDetectConfidence {
+		t.Errorf("Expected a confident guess for a full sentence, got %v", long[0])
+	}
+
+	// A few letters are weak evidence, whichever language wins
+	for _, text := range []string{"a", "in", "war", "snow", "die Tür", "la casa"} {
+		guesses := DetectLanguage(text)
+		if guesses[0].Confidence >= 0.5 {
+			t.Errorf("%q: expected an uncertain guess, got %v", text, guesses[0])
+		}
+		if guesses[0].Confidence > long[0].Confidence {
+			t.Errorf("%q: expected less certainty than a sentence, got %v", text, guesses[0])
+		}
+	}
+}
+
+func TestCountWordsWithLanguage(t *testing.T) {
+	text := "L'homme et la femme regardent l'horizon avec les enfants."
+	expected := map[string]int{"homme": 1, "femme": 1, "regardent": 1, "horizon": 1, "enfants": 1}
+
+	if result := CountWordsWithOptions(text, Options{Language: "fr"}); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if result := CountWordsWithOptions(text, Options{Language: AutoDetect}); !maps.Equal(result, expected) {
+		t.Errorf("Expected detection to pick French, got %v", result)
+	}
+	result, err := CountReaderWithOptions(strings.NewReader(text), Options{Language: AutoDetect})
+	if err != nil || !maps.Equal(result, expected) {
+		t.Errorf("Expected %v from a reader, got %v (%v)", expected, result, err)
+	}
+
+	// Explicit filters still run after the language's stopwords
+	opts := Options{Language: "fr", Filters: []TokenFilter{LengthFilter{Min: 7}}}
+	if result := CountWordsWithOptions(text, opts); !maps.Equal(result, map[string]int{"regardent": 1, "horizon": 1, "enfants": 1}) {
+		t.Errorf("Expected only long French words, got %v", result)
+	}
+	// Uncertain detection applies no language rules, so the English
+	// stopword survives
+	if result := CountWordsWithOptions("in", Options{Language: AutoDetect}); !maps.Equal(result, map[string]int{"in": 1}) {
+		t.Errorf("Expected short text to be counted without language rules, got %v", result)
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	for _, code := range []string{"", AutoDetect, "en", "fr"} {
+		if err := (Options{Language: code}).Validate(); err != nil {
+			t.Errorf("%q: Validate returned error: %v", code, err)
+		}
+	}
+	if err := (Options{Language: "eng"}).Validate(); err == nil {
+		t.Errorf("Expected an error for an unknown language")
+	}
+
+	text := "the cat"
+	if _, err := CountReaderWithOptions(strings.NewReader(text), Options{Language: "eng"}); err == nil {
+		t.Errorf("Expected CountReaderWithOptions to reject an unknown language")
+	}
+	// CountWordsWithOptions can't fail, so it counts with no language rules
+	if result := CountWordsWithOptions(text, Options{Language: "eng"}); !maps.Equal(result, CountWords(text)) {
+		t.Errorf("Expected plain counts for an unknown language, got %v", result)
+	}
+}
+
+func TestRegisterLanguage(t *testing.T) {
+	if err := RegisterLanguage(Language{Code: ""}, "text"); err == nil {
+		t.Errorf("Expected an error for an empty code")
+	}
+	if err := RegisterLanguage(Language{Code: "xx"}, "123"); err == nil {
+		t.Errorf("Expected an error for a sample without letters")
+	}
+
+	t.Cleanup(func() {
+		languagesMu.Lock()
+		delete(languages, "tlh")
+		languagesMu.Unlock()
+	})
+	err := RegisterLanguage(Language{Code: "tlh", Name: "Klingon", Stopwords: []string{"ghaH"}},
+		"nuqneH Qapla' tlhIngan maH ghaH yIjatlh batlh Daqawlu'taH jIyajbe' nuqDaq 'oH puchpa''e' Qapla' batlh")
+	if err != nil {
+		t.Fatalf("RegisterLanguage returned error: %v", err)
+	}
+	if lang, found := LookupLanguage("tlh"); !found || lang.Name != "Klingon" {
+		t.Errorf("Expected to find Klingon, got %v", lang)
+	}
+	if guesses := DetectLanguage("Qapla' tlhIngan maH"); guesses[0].Language != "tlh" {
+		t.Errorf("Expected tlh first, got %v", guesses[:3])
+	}
+}
@@ -0,0 +1,90 @@
+package words
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Markup selects which parts of Markdown or HTML input to remove before
+// counting. Flags combine with |; the zero value counts the text as is.
+type Markup int
+
+const (
+	StripMarkdown Markup = 1 << iota // Link and image targets and reference definitions
+	StripCode                        // Fenced code blocks and inline code spans
+	StripHTML                        // Tags, comments, scripts and styles; entities are decoded
+	StripURLs                        // Bare URLs
+	CodeOnly                         // Keep only fenced code blocks and inline code spans
+
+	// Prose counts only the human-readable text of a document
+	Prose = StripMarkdown | StripCode | StripHTML | StripURLs
+)
+
+var (
+	inlineCodePattern   = regexp.MustCompile("``[^`]+``|`[^`\n]+`")
+	htmlBlockPattern    = regexp.MustCompile(`(?is)<!--.*?-->|<script\b.*?</script\s*>|<style\b.*?</style\s*>`)
+	htmlTagPattern      = regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)
+	mdImagePattern      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLinkPattern       = regexp.MustCompile(`\[([^\]]+)\](?:\([^)]*\)|\[[^\]]*\])`)
+	mdDefinitionPattern = regexp.MustCompile(`(?m)^ {0,3}\[[^\]]+\]:\s*\S+.*$`)
+	urlPattern          = regexp.MustCompile(`\b(?:https?|ftp)://[^\s<>()]+|\bwww\.[^\s<>()]+`)
+)
+
+// CleanMarkup removes the parts of text selected by mode. Markdown
+// punctuation such as # or * needs no stripping since the tokenizer already
+// skips it.
+func CleanMarkup(text string, mode Markup) string {
+	switch {
+	case mode&CodeOnly != 0:
+		text = extractCode(text)
+	case mode&StripCode != 0:
+		text = removeCode(text)
+	}
+	if mode&StripHTML != 0 {
+		text = htmlBlockPattern.ReplaceAllString(text, " ")
+		text = htmlTagPattern.ReplaceAllString(text, " ")
+		text = html.UnescapeString(text)
+	}
+	if mode&StripMarkdown != 0 {
+		text = mdDefinitionPattern.ReplaceAllString(text, "")
+		text = mdImagePattern.ReplaceAllString(text, "$1")
+		text = mdLinkPattern.ReplaceAllString(text, "$1")
+	}
+	if mode&StripURLs != 0 {
+		text = urlPattern.ReplaceAllString(text, " ")
+	}
+	return text
+}
+
+// splitFences separates lines inside ``` or ~~~ fences from the rest
+func splitFences(text string) (prose, code []string) {
+	fence := ""
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			fence = trimmed[:3]
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			fence = ""
+		case fence != "":
+			code = append(code, line)
+		default:
+			prose = append(prose, line)
+		}
+	}
+	return prose, code
+}
+
+func removeCode(text string) string {
+	prose, _ := splitFences(text)
+	return inlineCodePattern.ReplaceAllString(strings.Join(prose, ""), " ")
+}
+
+func extractCode(text string) string {
+	prose, code := splitFences(text)
+	for _, span := range inlineCodePattern.FindAllString(strings.Join(prose, ""), -1) {
+		code = append(code, strings.Trim(span, "`")+"\n")
+	}
+	return strings.Join(code, "")
+}
@@ -0,0 +1,72 @@
+package words
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+const markdownDoc = "# Worker pool\n" +
+	"\n" +
+	"Read the [pool docs](https://example.com/pool) or see ![diagram](img/pool.png).\n" +
+	"Call `NewURLFetcher` to start &amp; <b>stop</b> the pool. More at www.example.org today.\n" +
+	"<!-- internal note -->\n" +
+	"\n" +
+	"```go\n" +
+	"fetcher := NewURLFetcher(3, timeout)\n" +
+	"```\n" +
+	"\n" +
+	"[ref]: https://example.com/ref\n"
+
+func TestCleanMarkupProse(t *testing.T) {
+	result := CountWordsWithOptions(markdownDoc, Options{Markup: Prose})
+	expected := CountWords("Worker pool Read the pool docs or see diagram. Call to start & stop the pool. More at today.")
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCleanMarkupCodeOnly(t *testing.T) {
+	result := CountWordsWithOptions(markdownDoc, Options{Markup: CodeOnly})
+	expected := map[string]int{"fetcher": 1, "newurlfetcher": 2, "3": 1, "timeout": 1}
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCleanMarkupFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Markup
+		text     string
+		expected string
+	}{
+		{"none", 0, "<b>bold</b> `code`", "<b>bold</b> `code`"},
+		{"html entities", StripHTML, "caf&eacute; <a href=\"x\">link</a>", "café  link "},
+		{"html script", StripHTML, "a<script>var x = 1;</script>b", "a b"},
+		{"urls only", StripURLs, "see https://go.dev/doc, then", "see   then"},
+		{"markdown link keeps url", StripMarkdown, "[Go](https://go.dev)", "Go"},
+		{"inline code", StripCode, "run `go test` now", "run   now"},
+		{"tilde fence", StripCode, "a\n~~~\nhidden\n~~~\nb", "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CleanMarkup(tt.text, tt.mode)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCountReaderWithMarkup(t *testing.T) {
+	opts := Options{Markup: Prose}
+	result, err := CountReaderWithOptions(strings.NewReader(markdownDoc), opts)
+	if err != nil {
+		t.Fatalf("CountReaderWithOptions returned error: %v", err)
+	}
+	if expected := CountWordsWithOptions(markdownDoc, opts); !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,102 @@
+package words
+
+import (
+	"math"
+	"slices"
+	"strings"
+)
+
+func CountNGrams(text string, n int) map[string]int {
+	return CountNGramsWithOptions(text, n, Options{})
+}
+
+// CountNGramsWithOptions counts runs of n consecutive tokens, joined by a
+// space. Filters run first, so dropped stopwords don't break up n-grams.
+func CountNGramsWithOptions(text string, n int, opts Options) map[string]int {
+	result := map[string]int{}
+	if n <= 0 {
+		return result
+	}
+	window := make([]string, 0, n)
+	opts.each([]byte(text), func(token string) {
+		if len(window) == n {
+			window = append(window[:0], window[1:]...)
+		}
+		window = append(window, token)
+		if len(window) == n {
+			result[strings.Join(window, " ")] += 1
+		}
+	})
+	return result
+}
+
+type Collocation struct {
+	WordCount             // Word is the bigram "first second"
+	PMI           float64 // Pointwise mutual information in bits
+	LogLikelihood float64 // Dunning's G² statistic
+}
+
+func Collocations(text string, minCount int) []Collocation {
+	return CollocationsWithOptions(text, minCount, Options{})
+}
+
+// CollocationsWithOptions scores bigrams seen at least minCount times by how
+// much more often the words appear together than chance would predict. The
+// result is sorted by log-likelihood, the more reliable score for rare words.
+func CollocationsWithOptions(text string, minCount int, opts Options) []Collocation {
+	unigrams := map[string]int{}
+	bigrams := map[[2]string]int{}
+	total := 0
+	previous := ""
+	opts.each([]byte(text), func(token string) {
+		unigrams[token] += 1
+		if total > 0 {
+			bigrams[[2]string{previous, token}] += 1
+		}
+		previous = token
+		total += 1
+	})
+
+	result := []Collocation{}
+	for pair, count := range bigrams {
+		if count < minCount {
+			continue
+		}
+		c1, c2, n := float64(unigrams[pair[0]]), float64(unigrams[pair[1]]), float64(total)
+		result = append(result, Collocation{
+			WordCount:     WordCount{Word: pair[0] + " " + pair[1], Count: count},
+			PMI:           math.Log2(float64(count) * n / (c1 * c2)),
+			LogLikelihood: logLikelihood(float64(count), c1, c2, n),
+		})
+	}
+
+	slices.SortFunc(result, func(a, b Collocation) int {
+		if a.LogLikelihood != b.LogLikelihood {
+			if a.LogLikelihood > b.LogLikelihood {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	return result
+}
+
+// logLikelihood computes G² over the 2x2 contingency table of a bigram seen
+// c12 times whose words appear c1 and c2 times among n tokens.
+func logLikelihood(c12, c1, c2, n float64) float64 {
+	observed := [4]float64{c12, c1 - c12, c2 - c12, n - c1 - c2 + c12}
+	expected := [4]float64{
+		c1 * c2 / n,
+		c1 * (n - c2) / n,
+		(n - c1) * c2 / n,
+		(n - c1) * (n - c2) / n,
+	}
+	g := 0.0
+	for i, o := range observed {
+		if o > 0 && expected[i] > 0 {
+			g += o * math.Log(o/expected[i])
+		}
+	}
+	return 2 * g
+}
@@ -0,0 +1,85 @@
+package words
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCountNGrams(t *testing.T) {
+	text := "The rate limiter wraps the worker pool. The rate limiter is fast."
+
+	bigrams := CountNGrams(text, 2)
+	if bigrams["rate limiter"] != 2 {
+		t.Errorf("Expected 'rate limiter'=2, got %d", bigrams["rate limiter"])
+	}
+	if bigrams["worker pool"] != 1 {
+		t.Errorf("Expected 'worker pool'=1, got %d", bigrams["worker pool"])
+	}
+
+	trigrams := CountNGrams(text, 3)
+	if trigrams["the rate limiter"] != 2 {
+		t.Errorf("Expected 'the rate limiter'=2, got %d", trigrams["the rate limiter"])
+	}
+
+	top := TopWords(bigrams, 2)
+	expected := []WordCount{{"rate limiter", 2}, {"the rate", 2}}
+	if !slices.Equal(top, expected) {
+		t.Errorf("Expected %v, got %v", expected, top)
+	}
+}
+
+func TestCountNGramsEdgeCases(t *testing.T) {
+	text := "Hello world! Hello Go."
+	if result := CountNGrams(text, 1); !maps.Equal(result, CountWords(text)) {
+		t.Errorf("Unigrams should match CountWords, got %v", result)
+	}
+	if result := CountNGrams(text, 5); len(result) != 0 {
+		t.Errorf("Expected no 5-grams in 4 words, got %v", result)
+	}
+	if result := CountNGrams(text, 0); len(result) != 0 {
+		t.Errorf("Expected no 0-grams, got %v", result)
+	}
+}
+
+func TestCollocations(t *testing.T) {
+	filler := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta"}
+	var sb strings.Builder
+	for i := range 200 {
+		sb.WriteString(filler[i%len(filler)] + " " + filler[(i*3+1)%len(filler)] + " ")
+		if i%4 == 0 {
+			sb.WriteString("rate limiter ")
+		}
+	}
+
+	result := Collocations(sb.String(), 5)
+	if len(result) == 0 {
+		t.Fatal("Expected collocations, got none")
+	}
+	if result[0].Word != "rate limiter" {
+		t.Errorf("Expected 'rate limiter' to be the strongest collocation, got %v", result[0])
+	}
+	if result[0].PMI <= 0 {
+		t.Errorf("Expected positive PMI for 'rate limiter', got %f", result[0].PMI)
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i].LogLikelihood > result[i-1].LogLikelihood {
+			t.Fatalf("Collocations not sorted by log-likelihood at %d", i)
+		}
+		if result[i].Count < 5 {
+			t.Errorf("Expected counts >= 5, got %v", result[i])
+		}
+	}
+}
+
+func TestLogLikelihood(t *testing.T) {
+	// Independent words score zero
+	if g := logLikelihood(10, 100, 100, 1000); math.Abs(g) > 1e-9 {
+		t.Errorf("Expected 0 for independent words, got %f", g)
+	}
+	if g := logLikelihood(50, 100, 100, 1000); g <= 0 {
+		t.Errorf("Expected positive score for associated words, got %f", g)
+	}
+}
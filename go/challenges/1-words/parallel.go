@@ -0,0 +1,109 @@
+package words
+
+import (
+	"io"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+)
+
+const parallelChunkSize = 1 << 20
+
+func CountWordsParallel(r io.Reader, workers int) (map[string]int, error) {
+	return defaultTokenizer.CountParallel(r, workers)
+}
+
+// CountParallel reads r in chunks cut on word boundaries and counts each
+// chunk in its own goroutine. workers <= 0 means one per CPU.
+func (t *Tokenizer) CountParallel(r io.Reader, workers int) (map[string]int, error) {
+	return t.countParallel(r, workers, parallelChunkSize)
+}
+
+func (t *Tokenizer) countParallel(r io.Reader, workers, chunkSize int) (map[string]int, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	jobs := make(chan []byte, workers)
+	results := make(chan map[string]int, workers) // Buffered so workers never block on exit
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go t.countShard(jobs, results, &wg)
+	}
+
+	err := t.readChunks(r, chunkSize, jobs)
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	result := map[string]int{}
+	for shard := range results {
+		mergeCounts(result, shard)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t *Tokenizer) countShard(jobs <-chan []byte, results chan<- map[string]int, wg *sync.WaitGroup) {
+	defer wg.Done()
+	shard := map[string]int{}
+	for chunk := range jobs {
+		t.countInto(shard, chunk)
+	}
+	results <- shard
+}
+
+func (t *Tokenizer) readChunks(r io.Reader, chunkSize int, jobs chan<- []byte) error {
+	defer close(jobs)
+	var carry []byte
+	for {
+		buf := make([]byte, len(carry)+chunkSize)
+		copy(buf, carry)
+		n, err := io.ReadFull(r, buf[len(carry):])
+		buf = buf[:len(carry)+n]
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			if len(buf) > 0 {
+				jobs <- buf
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		cut := t.safeCut(buf)
+		if cut > 0 {
+			jobs <- buf[:cut]
+		}
+		carry = buf[cut:]
+	}
+}
+
+// safeCut returns the last offset in data where no word can span the cut,
+// or 0 if there is none.
+func (t *Tokenizer) safeCut(data []byte) int {
+	end := len(data)
+	for end > 0 {
+		r, size := utf8.DecodeLastRune(data[:end])
+		if r == utf8.RuneError {
+			// Possibly a rune split by the chunk boundary
+			end -= size
+			continue
+		}
+		if isIdeograph(r) || (!isWordRune(r) && !t.joins(r)) {
+			return end
+		}
+		end -= size
+	}
+	return 0
+}
+
+func mergeCounts(dst, src map[string]int) {
+	for word, count := range src {
+		dst[word] += count
+	}
+}
@@ -0,0 +1,87 @@
+package words
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCountWordsParallel(t *testing.T) {
+	text := strings.Repeat("Hello world! Hello Go programming. Go is great, Go is powerful.\n", 500)
+	expected := CountWords(text)
+
+	result, err := CountWordsParallel(strings.NewReader(text), 4)
+	if err != nil {
+		t.Fatalf("CountWordsParallel returned error: %v", err)
+	}
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCountWordsParallelChunkBoundaries(t *testing.T) {
+	text := "Don’t split 我爱Go well-known Ünïcödé words—ever, don't. " + randomText(2000, 1)
+	tokenizer := &Tokenizer{KeepContractions: true, KeepHyphenated: true}
+	expected := tokenizer.Count(text)
+
+	// Tiny chunks put a boundary inside almost every word and rune
+	for _, chunkSize := range []int{1, 3, 7, 64} {
+		result, err := tokenizer.countParallel(strings.NewReader(text), 3, chunkSize)
+		if err != nil {
+			t.Fatalf("chunk size %d: unexpected error: %v", chunkSize, err)
+		}
+		if !maps.Equal(result, expected) {
+			t.Errorf("chunk size %d: result differs from sequential count", chunkSize)
+		}
+	}
+}
+
+func TestCountWordsParallelError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader("some words "), iotest.ErrReader(io.ErrClosedPipe))
+	if _, err := CountWordsParallel(r, 2); err == nil {
+		t.Error("Expected read error to be returned")
+	}
+}
+
+func BenchmarkCountWords(b *testing.B) {
+	text := randomText(1_000_000, 42)
+	b.SetBytes(int64(len(text)))
+	b.ResetTimer()
+	for range b.N {
+		CountWords(text)
+	}
+}
+
+func BenchmarkCountWordsParallel(b *testing.B) {
+	data := []byte(randomText(1_000_000, 42))
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for range b.N {
+		if _, err := CountWordsParallel(bytes.NewReader(data), runtime.GOMAXPROCS(0)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func randomText(words int, seed int64) string {
+	vocabulary := []string{
+		"the", "Go", "rate", "limiter", "worker", "pool", "don't", "well-known",
+		"Straße", "naïve", "我", "爱", "channel,", "goroutine.", "(context)", "—",
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var sb strings.Builder
+	for i := range words {
+		sb.WriteString(vocabulary[rng.Intn(len(vocabulary))])
+		if i%17 == 16 {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,70 @@
+package words
+
+import (
+	"bufio"
+	"io"
+)
+
+const (
+	maxTokenSize  = 64 * 1024
+	progressEvery = 64 * 1024 // tokens between progress reports
+)
+
+type Progress struct {
+	Bytes  int64 // Bytes consumed from the reader
+	Tokens int64 // Tokens counted so far
+}
+
+func CountReader(r io.Reader) (map[string]int, error) {
+	return defaultTokenizer.CountReaderWithProgress(r, nil)
+}
+
+func CountReaderWithProgress(r io.Reader, progress func(Progress)) (map[string]int, error) {
+	return defaultTokenizer.CountReaderWithProgress(r, progress)
+}
+
+func (t *Tokenizer) CountReader(r io.Reader) (map[string]int, error) {
+	return t.CountReaderWithProgress(r, nil)
+}
+
+// CountReaderWithProgress counts words without holding the whole input in
+// memory. progress, if non-nil, is called periodically and once at the end.
+func (t *Tokenizer) CountReaderWithProgress(r io.Reader, progress func(Progress)) (map[string]int, error) {
+	counter := &countingReader{r: r}
+	scanner := t.newScanner(counter)
+
+	result := map[string]int{}
+	var tokens int64
+	for scanner.Scan() {
+		result[t.Normalize(scanner.Text())] += 1
+		tokens++
+		if progress != nil && tokens%progressEvery == 0 {
+			progress(Progress{Bytes: counter.n, Tokens: tokens})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		progress(Progress{Bytes: counter.n, Tokens: tokens})
+	}
+	return result, nil
+}
+
+func (t *Tokenizer) newScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxTokenSize)
+	scanner.Split(t.Split)
+	return scanner
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
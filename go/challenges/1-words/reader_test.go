@@ -0,0 +1,64 @@
+package words
+
+import (
+	"bufio"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCountReader(t *testing.T) {
+	text := "Hello world! Hello Go programming. Go is great, Go is powerful."
+	expected := CountWords(text)
+
+	// One byte per read forces every word to span a buffer boundary
+	result, err := CountReader(iotest.OneByteReader(strings.NewReader(text)))
+	if err != nil {
+		t.Fatalf("CountReader returned error: %v", err)
+	}
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
+
+func TestCountReaderProgress(t *testing.T) {
+	text := strings.Repeat("go is fun ", 1000)
+
+	var last Progress
+	calls := 0
+	result, err := CountReaderWithProgress(strings.NewReader(text), func(p Progress) {
+		calls++
+		if p.Bytes < last.Bytes || p.Tokens < last.Tokens {
+			t.Errorf("Progress went backwards: %+v after %+v", p, last)
+		}
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("CountReaderWithProgress returned error: %v", err)
+	}
+	if result["go"] != 1000 {
+		t.Errorf("Expected go=1000, got %d", result["go"])
+	}
+	if calls == 0 {
+		t.Fatal("Progress callback was never called")
+	}
+	if last.Bytes != int64(len(text)) || last.Tokens != 3000 {
+		t.Errorf("Expected final progress {%d 3000}, got %+v", len(text), last)
+	}
+}
+
+func TestCountReaderError(t *testing.T) {
+	_, err := CountReader(iotest.ErrReader(io.ErrUnexpectedEOF))
+	if err == nil {
+		t.Error("Expected read error to be returned")
+	}
+}
+
+func TestCountReaderTokenTooLong(t *testing.T) {
+	_, err := CountReader(strings.NewReader(strings.Repeat("a", maxTokenSize+1)))
+	if err != bufio.ErrTooLong {
+		t.Errorf("Expected bufio.ErrTooLong, got %v", err)
+	}
+}
@@ -0,0 +1,374 @@
+package words
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Binary snapshots start with snapshotMagic, a uvarint version and a flags
+// byte, followed by records of uvarint(len(word)+1), word and
+// uvarint(count). A zero length marks the end, so truncated files are
+// detected. Words are limited to maxTokenSize bytes, like the tokenizer's.
+const (
+	snapshotMagic   = "WCNT"
+	snapshotVersion = 1
+
+	snapshotSorted = 1 << 0 // Records are in ascending word order
+)
+
+type jsonSnapshot struct {
+	Version int         `json:"version"`
+	Sorted  bool        `json:"sorted"`
+	Words   []WordCount `json:"words"`
+}
+
+// WriteSnapshot writes frequencies in the binary format, sorted by word so
+// snapshots can be merged with MergeSnapshots.
+func WriteSnapshot(w io.Writer, frequencies map[string]int) error {
+	return writeBinary(w, sortedWordCounts(frequencies), true)
+}
+
+// WriteWordCounts writes counts in the binary format, keeping their order.
+func WriteWordCounts(w io.Writer, counts []WordCount) error {
+	return writeBinary(w, counts, false)
+}
+
+func WriteSnapshotJSON(w io.Writer, frequencies map[string]int) error {
+	return writeJSON(w, sortedWordCounts(frequencies), true)
+}
+
+func WriteWordCountsJSON(w io.Writer, counts []WordCount) error {
+	return writeJSON(w, counts, false)
+}
+
+// ReadSnapshot reads a frequency map written in either format.
+func ReadSnapshot(r io.Reader) (map[string]int, error) {
+	counts, err := ReadWordCounts(r)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int, len(counts))
+	for _, wc := range counts {
+		result[wc.Word] += wc.Count
+	}
+	return result, nil
+}
+
+// ReadWordCounts reads counts written in either format, in written order.
+func ReadWordCounts(r io.Reader) ([]WordCount, error) {
+	br := bufio.NewReader(r)
+	prefix, err := br.Peek(len(snapshotMagic))
+	if err != nil && len(prefix) == 0 {
+		return nil, fmt.Errorf("read snapshot: %v", err)
+	}
+	if string(prefix) != snapshotMagic {
+		return readJSON(br)
+	}
+
+	sr, err := NewSnapshotReader(br)
+	if err != nil {
+		return nil, err
+	}
+	counts := []WordCount{}
+	for {
+		wc, err := sr.Next()
+		if err == io.EOF {
+			return counts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, wc)
+	}
+}
+
+func writeBinary(w io.Writer, counts []WordCount, sorted bool) error {
+	sw, err := NewSnapshotWriter(w, sorted)
+	if err != nil {
+		return err
+	}
+	for _, wc := range counts {
+		if err := sw.Write(wc); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+func writeJSON(w io.Writer, counts []WordCount, sorted bool) error {
+	err := json.NewEncoder(w).Encode(jsonSnapshot{Version: snapshotVersion, Sorted: sorted, Words: counts})
+	if err != nil {
+		return fmt.Errorf("write snapshot: %v", err)
+	}
+	return nil
+}
+
+func readJSON(r io.Reader) ([]WordCount, error) {
+	var snapshot jsonSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("read snapshot: %v", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("read snapshot: unsupported version %d", snapshot.Version)
+	}
+	if snapshot.Words == nil {
+		snapshot.Words = []WordCount{}
+	}
+	check := recordCheck{sorted: snapshot.Sorted}
+	for _, wc := range snapshot.Words {
+		if err := check.next(wc); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot.Words, nil
+}
+
+// recordCheck validates records as they're read, the same for both formats.
+type recordCheck struct {
+	sorted  bool
+	started bool
+	last    string
+}
+
+func (c *recordCheck) next(wc WordCount) error {
+	if wc.Count < 0 {
+		return fmt.Errorf("read snapshot: negative count %d for %q", wc.Count, wc.Word)
+	}
+	if len(wc.Word) > maxTokenSize {
+		return fmt.Errorf("read snapshot: word of %d bytes exceeds %d", len(wc.Word), maxTokenSize)
+	}
+	if c.sorted && c.started && wc.Word <= c.last {
+		return fmt.Errorf("read snapshot: %q out of order after %q", wc.Word, c.last)
+	}
+	c.last, c.started = wc.Word, true
+	return nil
+}
+
+func sortedWordCounts(frequencies map[string]int) []WordCount {
+	counts := make([]WordCount, 0, len(frequencies))
+	for word, count := range frequencies {
+		counts = append(counts, WordCount{Word: word, Count: count})
+	}
+	slices.SortFunc(counts, func(a, b WordCount) int {
+		return strings.Compare(a.Word, b.Word)
+	})
+	return counts
+}
+
+// SnapshotWriter streams records into the binary format. Close must be
+// called to write the end marker.
+type SnapshotWriter struct {
+	w       *bufio.Writer
+	sorted  bool
+	last    string
+	written bool
+	buf     []byte
+}
+
+// NewSnapshotWriter writes the header. If sorted is set, Write rejects
+// words that don't come after the previous one.
+func NewSnapshotWriter(w io.Writer, sorted bool) (*SnapshotWriter, error) {
+	sw := &SnapshotWriter{w: bufio.NewWriter(w), sorted: sorted}
+	header := binary.AppendUvarint([]byte(snapshotMagic), snapshotVersion)
+	flags := byte(0)
+	if sorted {
+		flags |= snapshotSorted
+	}
+	if _, err := sw.w.Write(append(header, flags)); err != nil {
+		return nil, fmt.Errorf("write snapshot: %v", err)
+	}
+	return sw, nil
+}
+
+func (sw *SnapshotWriter) Write(wc WordCount) error {
+	if wc.Count < 0 {
+		return fmt.Errorf("write snapshot: negative count %d for %q", wc.Count, wc.Word)
+	}
+	if len(wc.Word) > maxTokenSize {
+		return fmt.Errorf("write snapshot: word of %d bytes exceeds %d", len(wc.Word), maxTokenSize)
+	}
+	if sw.sorted && sw.written && wc.Word <= sw.last {
+		return fmt.Errorf("write snapshot: %q out of order after %q", wc.Word, sw.last)
+	}
+	sw.buf = binary.AppendUvarint(sw.buf[:0], uint64(len(wc.Word))+1)
+	sw.buf = append(sw.buf, wc.Word...)
+	sw.buf = binary.AppendUvarint(sw.buf, uint64(wc.Count))
+	if _, err := sw.w.Write(sw.buf); err != nil {
+		return fmt.Errorf("write snapshot: %v", err)
+	}
+	sw.last, sw.written = wc.Word, true
+	return nil
+}
+
+func (sw *SnapshotWriter) Close() error {
+	if err := sw.w.WriteByte(0); err != nil {
+		return fmt.Errorf("write snapshot: %v", err)
+	}
+	if err := sw.w.Flush(); err != nil {
+		return fmt.Errorf("write snapshot: %v", err)
+	}
+	return nil
+}
+
+// SnapshotReader streams records from the binary format.
+type SnapshotReader struct {
+	r     *bufio.Reader
+	check recordCheck
+	done  bool
+}
+
+func NewSnapshotReader(r io.Reader) (*SnapshotReader, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, []byte(snapshotMagic)) {
+		return nil, fmt.Errorf("read snapshot: not a binary snapshot")
+	}
+	version, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %v", err)
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("read snapshot: unsupported version %d", version)
+	}
+	flags, err := br.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot: %v", err)
+	}
+	return &SnapshotReader{r: br, check: recordCheck{sorted: flags&snapshotSorted != 0}}, nil
+}
+
+// Sorted reports whether the records are in ascending word order.
+func (sr *SnapshotReader) Sorted() bool {
+	return sr.check.sorted
+}
+
+// Next returns the next record, or io.EOF after the last one.
+func (sr *SnapshotReader) Next() (WordCount, error) {
+	if sr.done {
+		return WordCount{}, io.EOF
+	}
+	length, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return WordCount{}, fmt.Errorf("read snapshot: %v", unexpectedEOF(err))
+	}
+	if length == 0 {
+		sr.done = true
+		return WordCount{}, io.EOF
+	}
+	if length-1 > maxTokenSize {
+		return WordCount{}, fmt.Errorf("read snapshot: word of %d bytes exceeds %d", length-1, maxTokenSize)
+	}
+	word := make([]byte, length-1)
+	if _, err := io.ReadFull(sr.r, word); err != nil {
+		return WordCount{}, fmt.Errorf("read snapshot: %v", unexpectedEOF(err))
+	}
+	count, err := binary.ReadUvarint(sr.r)
+	if err != nil {
+		return WordCount{}, fmt.Errorf("read snapshot: %v", unexpectedEOF(err))
+	}
+	if count > math.MaxInt {
+		return WordCount{}, fmt.Errorf("read snapshot: count %d for %q out of range", count, word)
+	}
+	wc := WordCount{Word: string(word), Count: int(count)}
+	if err := sr.check.next(wc); err != nil {
+		return WordCount{}, err
+	}
+	return wc, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// MergeSnapshots sums any number of sorted binary snapshots into w,
+// holding only one record per input in memory at a time.
+func MergeSnapshots(w io.Writer, inputs ...io.Reader) error {
+	queue := &mergeQueue{}
+	for i, input := range inputs {
+		sr, err := NewSnapshotReader(input)
+		if err != nil {
+			return fmt.Errorf("merge input %d: %v", i, err)
+		}
+		if !sr.Sorted() {
+			return fmt.Errorf("merge input %d: snapshot is not sorted", i)
+		}
+		if err := queue.advance(&mergeSource{reader: sr, index: i}); err != nil {
+			return err
+		}
+	}
+
+	sw, err := NewSnapshotWriter(w, true)
+	if err != nil {
+		return err
+	}
+	for queue.Len() > 0 {
+		current := WordCount{Word: (*queue)[0].head.Word}
+		for queue.Len() > 0 && (*queue)[0].head.Word == current.Word {
+			source := heap.Pop(queue).(*mergeSource)
+			if current.Count > math.MaxInt-source.head.Count {
+				return fmt.Errorf("merge snapshots: count for %q overflows", current.Word)
+			}
+			current.Count += source.head.Count
+			if err := queue.advance(source); err != nil {
+				return err
+			}
+		}
+		if err := sw.Write(current); err != nil {
+			return err
+		}
+	}
+	return sw.Close()
+}
+
+type mergeSource struct {
+	reader *SnapshotReader
+	head   WordCount
+	index  int
+}
+
+// mergeQueue is a min-heap of sources ordered by their next word
+type mergeQueue []*mergeSource
+
+// advance reads the next record of source and queues it, unless it's done.
+// The reader rejects records out of order.
+func (q *mergeQueue) advance(source *mergeSource) error {
+	wc, err := source.reader.Next()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("merge input %d: %v", source.index, err)
+	}
+	source.head = wc
+	heap.Push(q, source)
+	return nil
+}
+
+func (q mergeQueue) Len() int { return len(q) }
+
+func (q mergeQueue) Less(i, j int) bool { return q[i].head.Word < q[j].head.Word }
+
+func (q mergeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *mergeQueue) Push(x any) { *q = append(*q, x.(*mergeSource)) }
+
+func (q *mergeQueue) Pop() any {
+	old := *q
+	source := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return source
+}
@@ -0,0 +1,162 @@
+package words
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	frequencies := CountWords("Hello world! Hello Go. Straße café 我爱你")
+	writers := map[string]func(io.Writer, map[string]int) error{
+		"binary": WriteSnapshot,
+		"json":   WriteSnapshotJSON,
+	}
+	for name, write := range writers {
+		var buf bytes.Buffer
+		if err := write(&buf, frequencies); err != nil {
+			t.Fatalf("%s: write returned error: %v", name, err)
+		}
+		result, err := ReadSnapshot(&buf)
+		if err != nil {
+			t.Fatalf("%s: read returned error: %v", name, err)
+		}
+		if !maps.Equal(result, frequencies) {
+			t.Errorf("%s: expected %v, got %v", name, frequencies, result)
+		}
+	}
+}
+
+func TestWordCountsRoundTrip(t *testing.T) {
+	counts := []WordCount{{"go", 3}, {"hello", 2}, {"", 0}, {"a", 1}}
+	writers := map[string]func(io.Writer, []WordCount) error{
+		"binary": WriteWordCounts,
+		"json":   WriteWordCountsJSON,
+	}
+	for name, write := range writers {
+		var buf bytes.Buffer
+		if err := write(&buf, counts); err != nil {
+			t.Fatalf("%s: write returned error: %v", name, err)
+		}
+		result, err := ReadWordCounts(&buf)
+		if err != nil {
+			t.Fatalf("%s: read returned error: %v", name, err)
+		}
+		if !slices.Equal(result, counts) {
+			t.Errorf("%s: expected %v, got %v", name, counts, result)
+		}
+	}
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	var buf bytes.Buffer
+	WriteSnapshot(&buf, map[string]int{"go": 1, "rust": 2})
+	data := buf.Bytes()
+
+	tests := map[string][]byte{
+		"empty":     {},
+		"truncated": data[:len(data)-2],
+		"version":   append([]byte("WCNT\x02\x01"), data[6:]...),
+		"json":      []byte(`{"version":2,"words":[]}`),
+		"garbage":   []byte("not a snapshot"),
+		// A 9-byte varint length would overflow an allocation
+		"length": []byte("WCNT\x01\x00\xff\xff\xff\xff\xff\xff\xff\xff\x7f"),
+		// Word "a" with a count above math.MaxInt
+		"count": []byte("WCNT\x01\x00\x02a\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01\x00"),
+		// Sorted flag set, but "b" comes before "a"
+		"unsorted":            []byte("WCNT\x01\x01\x02b\x01\x02a\x01\x00"),
+		"json negative count": []byte(`{"version":1,"words":[{"word":"go","count":-1}]}`),
+		"json long word":      []byte(`{"version":1,"words":[{"word":"` + strings.Repeat("a", maxTokenSize+1) + `","count":1}]}`),
+		"json unsorted":       []byte(`{"version":1,"sorted":true,"words":[{"word":"b","count":1},{"word":"a","count":1}]}`),
+	}
+	for name, input := range tests {
+		if _, err := ReadSnapshot(bytes.NewReader(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if err := WriteWordCounts(io.Discard, []WordCount{{"go", -1}}); err == nil {
+		t.Errorf("Expected an error for a negative count")
+	}
+	if err := WriteWordCounts(io.Discard, []WordCount{{strings.Repeat("a", maxTokenSize+1), 1}}); err == nil {
+		t.Errorf("Expected an error for a word longer than maxTokenSize")
+	}
+	sw, _ := NewSnapshotWriter(io.Discard, true)
+	sw.Write(WordCount{"rust", 1})
+	if err := sw.Write(WordCount{"go", 1}); err == nil {
+		t.Errorf("Expected an error for out of order words")
+	}
+}
+
+func TestMergeSnapshots(t *testing.T) {
+	texts := []string{"go go rust", "rust zig", "", "ada go"}
+	inputs := []io.Reader{}
+	expected := map[string]int{}
+	for _, text := range texts {
+		frequencies := CountWords(text)
+		mergeCounts(expected, frequencies)
+		var buf bytes.Buffer
+		if err := WriteSnapshot(&buf, frequencies); err != nil {
+			t.Fatalf("WriteSnapshot returned error: %v", err)
+		}
+		inputs = append(inputs, &buf)
+	}
+
+	var merged bytes.Buffer
+	if err := MergeSnapshots(&merged, inputs...); err != nil {
+		t.Fatalf("MergeSnapshots returned error: %v", err)
+	}
+	sr, err := NewSnapshotReader(&merged)
+	if err != nil {
+		t.Fatalf("NewSnapshotReader returned error: %v", err)
+	}
+	if !sr.Sorted() {
+		t.Errorf("Expected merged snapshot to be sorted")
+	}
+	result := []WordCount{}
+	for {
+		wc, err := sr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next returned error: %v", err)
+		}
+		result = append(result, wc)
+	}
+	if !slices.Equal(result, sortedWordCounts(expected)) {
+		t.Errorf("Expected %v, got %v", sortedWordCounts(expected), result)
+	}
+}
+
+func TestMergeSnapshotsRejectsUnsorted(t *testing.T) {
+	var unsorted bytes.Buffer
+	WriteWordCounts(&unsorted, []WordCount{{"rust", 1}, {"go", 1}})
+	if err := MergeSnapshots(io.Discard, &unsorted); err == nil {
+		t.Errorf("Expected an error for an unsorted snapshot")
+	}
+	corrupt := strings.NewReader("WCNT\x01\x01\xff\xff\xff\xff\xff\xff\xff\xff\x7f")
+	if err := MergeSnapshots(io.Discard, corrupt); err == nil {
+		t.Errorf("Expected an error for a corrupt snapshot")
+	}
+	if err := MergeSnapshots(io.Discard, strings.NewReader(`{"version":1}`)); err == nil {
+		t.Errorf("Expected an error for a JSON snapshot")
+	}
+}
+
+func TestMergeSnapshotsOverflow(t *testing.T) {
+	inputs := []io.Reader{}
+	for range 2 {
+		var buf bytes.Buffer
+		WriteSnapshot(&buf, map[string]int{"go": math.MaxInt})
+		inputs = append(inputs, &buf)
+	}
+	err := MergeSnapshots(io.Discard, inputs...)
+	if err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Errorf("Expected an overflow error, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package words
+
+import (
+	"slices"
+	"strings"
+)
+
+type Suggestion struct {
+	WordCount     // Count is the frequency of the word in the corpus
+	Distance  int // Edits needed to turn the input into Word
+}
+
+// Speller suggests corrections using a SymSpell-style index: every word is
+// stored under all strings made by deleting up to maxDistance runes from it,
+// so a lookup only has to generate deletions of the input instead of every
+// possible edit.
+type Speller struct {
+	maxDistance int
+	frequencies map[string]int
+	deletes     map[string][]string
+}
+
+func NewSpeller(frequencies map[string]int, maxDistance int) *Speller {
+	s := &Speller{
+		maxDistance: max(maxDistance, 0),
+		frequencies: map[string]int{},
+		deletes:     map[string][]string{},
+	}
+	for word, count := range frequencies {
+		s.Add(word, count)
+	}
+	return s
+}
+
+func (s *Speller) Add(word string, count int) {
+	if count <= 0 {
+		return
+	}
+	if _, found := s.frequencies[word]; !found {
+		for variant := range deletions(word, s.maxDistance) {
+			s.deletes[variant] = append(s.deletes[variant], word)
+		}
+	}
+	s.frequencies[word] += count
+}
+
+// Suggest returns up to n known words within the maximum edit distance of
+// word, closest first and then most frequent. The input is lower-cased to
+// match the output of CountWords.
+func (s *Speller) Suggest(word string, n int) []Suggestion {
+	word = strings.ToLower(word)
+	seen := map[string]bool{}
+	result := []Suggestion{}
+	for variant := range deletions(word, s.maxDistance) {
+		for _, candidate := range s.deletes[variant] {
+			if seen[candidate] {
+				continue
+			}
+			seen[candidate] = true
+			if d := editDistance(word, candidate); d <= s.maxDistance {
+				result = append(result, Suggestion{
+					WordCount: WordCount{Word: candidate, Count: s.frequencies[candidate]},
+					Distance:  d,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Suggestion) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	if len(result) > max(n, 0) {
+		result = result[:max(n, 0)]
+	}
+	return result
+}
+
+// Correct returns the best suggestion for word, or word itself if there is
+// none.
+func (s *Speller) Correct(word string) string {
+	if suggestions := s.Suggest(word, 1); len(suggestions) > 0 {
+		return suggestions[0].Word
+	}
+	return word
+}
+
+// deletions returns word and every string made by deleting up to n runes
+func deletions(word string, n int) map[string]struct{} {
+	result := map[string]struct{}{word: {}}
+	level := []string{word}
+	for range n {
+		next := []string{}
+		for _, w := range level {
+			runes := []rune(w)
+			for i := range runes {
+				variant := string(runes[:i]) + string(runes[i+1:])
+				if _, found := result[variant]; !found {
+					result[variant] = struct{}{}
+					next = append(next, variant)
+				}
+			}
+		}
+		level = next
+	}
+	return result
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and adjacent transpositions of runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Three rolling rows: two back, previous and current
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,65 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpellerSuggest(t *testing.T) {
+	speller := NewSpeller(CountWords(
+		"the goroutine reads the channel and the worker closes the channel. "+
+			"then the worker pool waits. the goroutines wait for work. Straße café",
+	), 2)
+
+	result := speller.Suggest("chanel", 3)
+	if len(result) == 0 || result[0].Word != "channel" || result[0].Distance != 1 || result[0].Count != 2 {
+		t.Errorf("Expected channel at distance 1 first, got %v", result)
+	}
+
+	// Closer words come first: "the" is one transposition away, "then" two edits
+	result = speller.Suggest("teh", 2)
+	expected := []Suggestion{{WordCount{"the", 6}, 1}, {WordCount{"then", 1}, 2}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if result := speller.Suggest("worker", 1); result[0].Distance != 0 {
+		t.Errorf("Expected a known word at distance 0, got %v", result)
+	}
+	if result := speller.Suggest("kubernetes", 5); len(result) != 0 {
+		t.Errorf("Expected no suggestions, got %v", result)
+	}
+}
+
+func TestSpellerUnicode(t *testing.T) {
+	speller := NewSpeller(map[string]int{"straße": 3, "café": 2}, 1)
+
+	if c := speller.Correct("Strasse"); c != "Strasse" {
+		// Two edits away, beyond the limit
+		t.Errorf("Expected no correction for Strasse, got %q", c)
+	}
+	if c := speller.Correct("STRAßE"); c != "straße" {
+		t.Errorf("Expected straße, got %q", c)
+	}
+	if c := speller.Correct("cafe"); c != "café" {
+		t.Errorf("Expected café, got %q", c)
+	}
+	if c := speller.Correct("caéf"); c != "café" {
+		t.Errorf("Expected transposition to be corrected to café, got %q", c)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0}, {"go", "", 2}, {"", "go", 2}, {"kitten", "sitting", 3},
+		{"ab", "ba", 1}, {"café", "cafe", 1}, {"我爱", "爱我", 1}, {"ca", "abc", 3},
+	}
+	for _, tt := range tests {
+		if d := editDistance(tt.a, tt.b); d != tt.expected {
+			t.Errorf("editDistance(%q, %q): expected %d, got %d", tt.a, tt.b, tt.expected, d)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package words
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TextStats struct {
+	Sentences          int
+	Words              int     // Tokens, the sum of CountWords
+	UniqueWords        int     // Types, the length of CountWords
+	Syllables          int     // Estimated, see syllables
+	AvgSentenceLength  float64 // Words per sentence
+	AvgWordLength      float64 // Runes per word
+	TypeTokenRatio     float64
+	HapaxLegomena      int // Words that occur exactly once
+	FleschReadingEase  float64
+	FleschKincaidGrade float64
+}
+
+func Stats(text string) TextStats {
+	return defaultTokenizer.Stats(text)
+}
+
+// Stats reports statistics for text using the words found by t, so the
+// counts agree with t.Count. A sentence ends at ., ! or ? followed by a
+// space, or at the end of the text; abbreviations like "Dr." are counted as
+// sentence ends too.
+func (t *Tokenizer) Stats(text string) TextStats {
+	var stats TextStats
+	counts := map[string]int{}
+	runes := 0
+	inSentence := false
+
+	data := []byte(text)
+	for len(data) > 0 {
+		advance, token, _ := t.Split(data, true)
+		gap := data[:advance-len(token)]
+		if inSentence && endsSentence(gap) {
+			stats.Sentences++
+			inSentence = false
+		}
+		if token != nil {
+			word := t.Normalize(string(token))
+			counts[word]++
+			stats.Words++
+			runes += utf8.RuneCountInString(word)
+			stats.Syllables += syllables(word)
+			inSentence = true
+		}
+		data = data[advance:]
+	}
+	if inSentence {
+		stats.Sentences++
+	}
+	if stats.Words == 0 {
+		return stats
+	}
+
+	stats.UniqueWords = len(counts)
+	for _, count := range counts {
+		if count == 1 {
+			stats.HapaxLegomena++
+		}
+	}
+	words, sentences := float64(stats.Words), float64(stats.Sentences)
+	stats.AvgSentenceLength = words / sentences
+	stats.AvgWordLength = float64(runes) / words
+	stats.TypeTokenRatio = float64(stats.UniqueWords) / words
+
+	syllablesPerWord := float64(stats.Syllables) / words
+	stats.FleschReadingEase = 206.835 - 1.015*stats.AvgSentenceLength - 84.6*syllablesPerWord
+	stats.FleschKincaidGrade = 0.39*stats.AvgSentenceLength + 11.8*syllablesPerWord - 15.59
+	return stats
+}
+
+// endsSentence reports whether the text between two words closes a
+// sentence. Full-width terminators end one on their own since CJK text has
+// no spaces; the others need a following space so "3.14" doesn't.
+func endsSentence(gap []byte) bool {
+	terminated := false
+	for _, r := range string(gap) {
+		switch {
+		case r == '。' || r == '！' || r == '？':
+			return true
+		case r == '.' || r == '!' || r == '?' || r == '…':
+			terminated = true
+		case terminated && unicode.IsSpace(r):
+			return true
+		}
+	}
+	return false
+}
+
+// syllables estimates the syllables in an English word by counting vowel
+// groups, ignoring a silent final "e". Words without Latin vowels, such as
+// ideographs and numbers, count as one.
+func syllables(word string) int {
+	word = strings.ToLower(word)
+	count := 0
+	previousVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !previousVowel {
+			count++
+		}
+		previousVowel = vowel
+	}
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") {
+		count--
+	}
+	return max(count, 1)
+}
@@ -0,0 +1,82 @@
+package words
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	text := "The cat sat on the mat. The dog ran! Pi is 3.14"
+	stats := Stats(text)
+
+	expected := TextStats{
+		Sentences:     3,
+		Words:         13, // "3.14" is two words
+		UniqueWords:   11,
+		Syllables:     13,
+		HapaxLegomena: 10,
+	}
+	if stats.Sentences != expected.Sentences || stats.Words != expected.Words ||
+		stats.UniqueWords != expected.UniqueWords || stats.Syllables != expected.Syllables ||
+		stats.HapaxLegomena != expected.HapaxLegomena {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+
+	// The numbers agree with CountWords
+	counts := CountWords(text)
+	if stats.Words != sumCounts(counts) || stats.UniqueWords != len(counts) {
+		t.Errorf("Expected %d words and %d unique, got %+v", sumCounts(counts), len(counts), stats)
+	}
+
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	floats := []struct {
+		name             string
+		result, expected float64
+	}{
+		{"average sentence length", stats.AvgSentenceLength, 13.0 / 3},
+		{"average word length", stats.AvgWordLength, 33.0 / 13},
+		{"type-token ratio", stats.TypeTokenRatio, 11.0 / 13},
+		{"Flesch reading ease", stats.FleschReadingEase, 206.835 - 1.015*13/3 - 84.6},
+		{"Flesch-Kincaid grade", stats.FleschKincaidGrade, 0.39*13/3 + 11.8 - 15.59},
+	}
+	for _, f := range floats {
+		if !near(f.result, f.expected) {
+			t.Errorf("Expected %s %v, got %v", f.name, f.expected, f.result)
+		}
+	}
+}
+
+func TestStatsReadability(t *testing.T) {
+	simple := Stats("The cat sat. The dog ran. We had fun.")
+	dense := Stats("Comprehensive institutional considerations necessitate extraordinarily complicated administrative documentation.")
+	if simple.FleschReadingEase <= dense.FleschReadingEase {
+		t.Errorf("Expected simple text to be easier, got %v and %v", simple.FleschReadingEase, dense.FleschReadingEase)
+	}
+	if simple.FleschKincaidGrade >= dense.FleschKincaidGrade {
+		t.Errorf("Expected simple text to have a lower grade, got %v and %v", simple.FleschKincaidGrade, dense.FleschKincaidGrade)
+	}
+}
+
+func TestStatsEdgeCases(t *testing.T) {
+	if stats := Stats("  ...  "); stats != (TextStats{}) {
+		t.Errorf("Expected empty stats, got %+v", stats)
+	}
+	if stats := Stats("我爱你。你好"); stats.Sentences != 2 || stats.Words != 5 {
+		t.Errorf("Expected 2 sentences and 5 words, got %+v", stats)
+	}
+	if stats := Stats("No terminator here"); stats.Sentences != 1 {
+		t.Errorf("Expected 1 sentence, got %d", stats.Sentences)
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := map[string]int{
+		"the": 1, "make": 1, "table": 2, "free": 1, "readability": 5,
+		"rhythm": 1, "3": 1, "我": 1, "programming": 3,
+	}
+	for word, expected := range tests {
+		if n := syllables(word); n != expected {
+			t.Errorf("syllables(%q): expected %d, got %d", word, expected, n)
+		}
+	}
+}
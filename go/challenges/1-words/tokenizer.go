@@ -0,0 +1,143 @@
+package words
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Tokenizer struct {
+	CaseSensitive    bool // Keep the original case instead of folding to lower case
+	KeepContractions bool // Keep "don't" as one token instead of "don" and "t"
+	KeepHyphenated   bool // Keep "well-known" as one token instead of "well" and "known"
+}
+
+// NewTokenizer returns the tokenizer used by CountWords and CountReader.
+func NewTokenizer() *Tokenizer {
+	return &Tokenizer{KeepContractions: true}
+}
+
+var defaultTokenizer = NewTokenizer()
+
+var punctuationReplacer = strings.NewReplacer("’", "'", "‐", "-", "‑", "-")
+
+func (t *Tokenizer) Tokens(text string) []string {
+	tokens := []string{}
+	t.each([]byte(text), func(token string) {
+		tokens = append(tokens, token)
+	})
+	return tokens
+}
+
+func (t *Tokenizer) Count(text string) map[string]int {
+	result := map[string]int{}
+	t.countInto(result, []byte(text))
+	return result
+}
+
+func (t *Tokenizer) countInto(result map[string]int, data []byte) {
+	t.each(data, func(token string) {
+		result[token] += 1
+	})
+}
+
+func (t *Tokenizer) each(data []byte, fn func(token string)) {
+	for len(data) > 0 {
+		advance, token, _ := t.Split(data, true)
+		if token != nil {
+			fn(t.Normalize(string(token)))
+		}
+		data = data[advance:]
+	}
+}
+
+// Normalize folds case and unifies apostrophe and hyphen variants so
+// "Don’t" and "don't" count as the same word.
+func (t *Tokenizer) Normalize(token string) string {
+	if strings.ContainsAny(token, "’‐‑") {
+		token = punctuationReplacer.Replace(token)
+	}
+	if !t.CaseSensitive {
+		token = strings.ToLower(token)
+	}
+	return token
+}
+
+// Split is a bufio.SplitFunc. A word is a run of letters, digits and marks,
+// optionally joined by apostrophes or hyphens. Ideographs are one word each
+// since those scripts don't separate words with spaces.
+func (t *Tokenizer) Split(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		r, size := utf8.DecodeRune(data[start:])
+		if isWordRune(r) {
+			break
+		}
+		start += size
+	}
+	if start == len(data) {
+		return start, nil, nil
+	}
+
+	r, size := utf8.DecodeRune(data[start:])
+	if isIdeograph(r) {
+		return start + size, data[start : start+size], nil
+	}
+
+	end := start + size
+	for end < len(data) {
+		if !atEOF && !utf8.FullRune(data[end:]) {
+			return start, nil, nil
+		}
+		r, size := utf8.DecodeRune(data[end:])
+		if isWordRune(r) && !isIdeograph(r) {
+			end += size
+			continue
+		}
+		if !t.joins(r) {
+			break
+		}
+		// A joiner only belongs to the word if another word rune follows it
+		next := end + size
+		if next == len(data) || (!atEOF && !utf8.FullRune(data[next:])) {
+			if atEOF {
+				break
+			}
+			return start, nil, nil
+		}
+		nr, nsize := utf8.DecodeRune(data[next:])
+		if !isWordRune(nr) || isIdeograph(nr) {
+			break
+		}
+		end = next + nsize
+	}
+	if end == len(data) && !atEOF {
+		// The word may continue in the next read
+		return start, nil, nil
+	}
+	return end, data[start:end], nil
+}
+
+func (t *Tokenizer) joins(r rune) bool {
+	switch r {
+	case '\'', '’':
+		return t.KeepContractions
+	case '-', '‐', '‑':
+		return t.KeepHyphenated
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+func isIdeograph(r rune) bool {
+	if r < '\u3040' { // Below Hiragana, the first of these blocks
+		return false
+	}
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
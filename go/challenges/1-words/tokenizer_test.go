@@ -0,0 +1,58 @@
+package words
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTokenizerTokens(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer *Tokenizer
+		text      string
+		expected  []string
+	}{
+		{"case folding", NewTokenizer(), "Hello HELLO hello", []string{"hello", "hello", "hello"}},
+		{"case sensitive", &Tokenizer{CaseSensitive: true}, "Hello HELLO", []string{"Hello", "HELLO"}},
+		{"punctuation", NewTokenizer(), `"Hello," she said (quietly)...`, []string{"hello", "she", "said", "quietly"}},
+		{"em-dash", NewTokenizer(), "wait—what", []string{"wait", "what"}},
+		{"contractions kept", NewTokenizer(), "Don’t stop, it's 'quoted'", []string{"don't", "stop", "it's", "quoted"}},
+		{"contractions split", &Tokenizer{}, "don't", []string{"don", "t"}},
+		{"hyphen split", NewTokenizer(), "well-known", []string{"well", "known"}},
+		{"hyphen kept", &Tokenizer{KeepHyphenated: true}, "a well-known -dash- case", []string{"a", "well-known", "dash", "case"}},
+		{"unicode letters", NewTokenizer(), "Ünïcödé café Straße", []string{"ünïcödé", "café", "straße"}},
+		{"cjk", NewTokenizer(), "我爱Go语言", []string{"我", "爱", "go", "语", "言"}},
+		{"digits", NewTokenizer(), "version 2 of 10", []string{"version", "2", "of", "10"}},
+		{"empty", NewTokenizer(), " \t\n ", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.tokenizer.Tokens(tt.text)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTokenizerSplitAcrossReads(t *testing.T) {
+	text := "Don’t split 我爱 well-known Ünïcödé words—ever. "
+	tokenizer := &Tokenizer{KeepContractions: true, KeepHyphenated: true}
+
+	expected := map[string]int{}
+	for _, token := range tokenizer.Tokens(text) {
+		expected[token] += 1
+	}
+
+	result, err := tokenizer.CountReader(iotest.OneByteReader(strings.NewReader(text)))
+	if err != nil {
+		t.Fatalf("CountReader returned error: %v", err)
+	}
+	if !maps.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,143 @@
+package words
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Trend struct {
+	WordCount         // Count within the current window
+	Expected  float64 // Count the baseline predicts for a window of the same length
+	Score     float64 // Burst score, positive for rising terms and negative for falling ones
+}
+
+// TrendDetector counts timestamped text in fixed-size time buckets and
+// compares the most recent window against the baseline window before it.
+type TrendDetector struct {
+	mu         sync.Mutex
+	opts       Options
+	bucketSize time.Duration
+	window     int64 // Number of buckets in the current window
+	baseline   int64 // Number of buckets in the baseline window
+	buckets    map[int64]map[string]int
+	latest     int64
+}
+
+// NewTrendDetector compares the last window of text against the baseline
+// period right before it. Both are rounded up to whole buckets.
+func NewTrendDetector(bucketSize, window, baseline time.Duration, opts Options) *TrendDetector {
+	bucketSize = max(bucketSize, time.Nanosecond)
+	return &TrendDetector{
+		opts:       opts,
+		bucketSize: bucketSize,
+		window:     max(int64((window+bucketSize-1)/bucketSize), 1),
+		baseline:   max(int64((baseline+bucketSize-1)/bucketSize), 1),
+		buckets:    map[int64]map[string]int{},
+	}
+}
+
+func (d *TrendDetector) Add(at time.Time, text string) {
+	counts := CountWordsWithOptions(text, d.opts)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	index := d.bucket(at)
+	if index > d.latest {
+		d.latest = index
+		d.pruneUnlocked()
+	}
+	if index <= d.latest-d.window-d.baseline {
+		return // Too old to be in any window
+	}
+	bucket, found := d.buckets[index]
+	if !found {
+		bucket = map[string]int{}
+		d.buckets[index] = bucket
+	}
+	mergeCounts(bucket, counts)
+}
+
+// Rising returns the n terms with the highest positive burst score at now.
+func (d *TrendDetector) Rising(now time.Time, n int) []Trend {
+	return topTrends(d.trends(now), n, func(t Trend) bool { return t.Score > 0 }, -1)
+}
+
+// Falling returns the n terms with the lowest negative burst score at now.
+func (d *TrendDetector) Falling(now time.Time, n int) []Trend {
+	return topTrends(d.trends(now), n, func(t Trend) bool { return t.Score < 0 }, 1)
+}
+
+func (d *TrendDetector) bucket(at time.Time) int64 {
+	return at.UnixNano() / int64(d.bucketSize)
+}
+
+func (d *TrendDetector) pruneUnlocked() {
+	for index := range d.buckets {
+		if index <= d.latest-d.window-d.baseline {
+			delete(d.buckets, index)
+		}
+	}
+}
+
+// trends scores every term seen in either window. The score is the
+// difference from the expected count in units of its standard deviation,
+// treating counts as Poisson, with one added so new terms don't divide by zero.
+func (d *TrendDetector) trends(now time.Time) []Trend {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	end := d.bucket(now)
+	current := map[string]int{}
+	baseline := map[string]int{}
+	for index, counts := range d.buckets {
+		switch {
+		case index > end:
+		case index > end-d.window:
+			mergeCounts(current, counts)
+		case index > end-d.window-d.baseline:
+			mergeCounts(baseline, counts)
+		}
+	}
+
+	scale := float64(d.window) / float64(d.baseline)
+	trends := []Trend{}
+	for word, count := range current {
+		trends = append(trends, newTrend(word, count, float64(baseline[word])*scale))
+	}
+	for word, count := range baseline {
+		if _, found := current[word]; !found {
+			trends = append(trends, newTrend(word, 0, float64(count)*scale))
+		}
+	}
+	return trends
+}
+
+func newTrend(word string, count int, expected float64) Trend {
+	return Trend{
+		WordCount: WordCount{Word: word, Count: count},
+		Expected:  expected,
+		Score:     (float64(count) - expected) / math.Sqrt(expected+1),
+	}
+}
+
+// topTrends keeps the trends matching keep, ordered by score in direction
+// (-1 for descending, 1 for ascending) and then by word.
+func topTrends(trends []Trend, n int, keep func(Trend) bool, direction int) []Trend {
+	trends = slices.DeleteFunc(trends, func(t Trend) bool { return !keep(t) })
+	slices.SortFunc(trends, func(a, b Trend) int {
+		if a.Score != b.Score {
+			if a.Score < b.Score {
+				return -direction
+			}
+			return direction
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	if len(trends) > max(n, 0) {
+		trends = trends[:max(n, 0)]
+	}
+	return trends
+}
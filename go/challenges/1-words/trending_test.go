@@ -0,0 +1,65 @@
+package words
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrendDetector(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	detector := NewTrendDetector(time.Minute, 2*time.Minute, 10*time.Minute, Options{})
+
+	// Ten quiet minutes of baseline
+	for i := range 10 {
+		detector.Add(start.Add(time.Duration(i)*time.Minute), "go rust chat")
+	}
+	// Then "go" spikes, "zig" appears and "rust" goes silent
+	for i := 10; i < 12; i++ {
+		at := start.Add(time.Duration(i) * time.Minute)
+		for range 10 {
+			detector.Add(at, "go")
+		}
+		detector.Add(at, "zig zig chat")
+	}
+
+	now := start.Add(11*time.Minute + 30*time.Second)
+	rising := detector.Rising(now, 10)
+	if len(rising) != 2 || rising[0].Word != "go" || rising[1].Word != "zig" {
+		t.Fatalf("Expected rising [go zig], got %v", rising)
+	}
+	if rising[0].Count != 20 || rising[0].Expected != 2 {
+		t.Errorf("Expected go count 20 vs 2 expected, got %+v", rising[0])
+	}
+
+	falling := detector.Falling(now, 10)
+	if len(falling) != 1 || falling[0].Word != "rust" || falling[0].Count != 0 {
+		t.Fatalf("Expected falling [rust], got %v", falling)
+	}
+	if falling[0].Score >= 0 {
+		t.Errorf("Expected negative score for rust, got %f", falling[0].Score)
+	}
+
+	// "chat" kept its rate and is neither rising nor falling
+	for _, trend := range append(rising, falling...) {
+		if trend.Word == "chat" {
+			t.Errorf("Expected steady 'chat' not to trend, got %+v", trend)
+		}
+	}
+}
+
+func TestTrendDetectorPrunesOldBuckets(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	detector := NewTrendDetector(time.Minute, time.Minute, time.Minute, Options{})
+
+	detector.Add(start, "old")
+	detector.Add(start.Add(10*time.Minute), "new")
+	detector.Add(start, "late") // Arrives after its window has passed
+
+	if len(detector.buckets) != 1 {
+		t.Errorf("Expected old buckets to be pruned, got %d buckets", len(detector.buckets))
+	}
+	rising := detector.Rising(start.Add(10*time.Minute), 10)
+	if len(rising) != 1 || rising[0].Word != "new" {
+		t.Errorf("Expected only 'new' to be rising, got %v", rising)
+	}
+}
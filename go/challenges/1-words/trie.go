@@ -0,0 +1,179 @@
+package words
+
+import "container/heap"
+
+// Trie stores word frequencies by prefix for autocomplete. Each node tracks
+// the highest count below it so Complete only visits promising branches.
+type Trie struct {
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	count    int // Frequency of the word ending here, 0 if none does
+	words    int // Number of words in this subtree
+	maxCount int // Highest count in this subtree
+}
+
+func NewTrie(frequencies map[string]int) *Trie {
+	t := &Trie{root: &trieNode{}}
+	for word, count := range frequencies {
+		t.Add(word, count)
+	}
+	return t
+}
+
+// Add increases the frequency of word by count. Counts <= 0 are ignored.
+func (t *Trie) Add(word string, count int) {
+	if count <= 0 {
+		return
+	}
+	path := t.path(word, true)
+	last := path[len(path)-1]
+	isNew := last.count == 0
+	last.count += count
+	for _, node := range path {
+		if isNew {
+			node.words += 1
+		}
+		node.maxCount = max(node.maxCount, last.count)
+	}
+}
+
+// Count returns the frequency of word, or 0 if it's not in the trie.
+func (t *Trie) Count(word string) int {
+	node := t.find(word)
+	if node == nil {
+		return 0
+	}
+	return node.count
+}
+
+// Len returns the number of distinct words.
+func (t *Trie) Len() int {
+	return t.root.words
+}
+
+// PrefixCount returns the number of distinct words starting with prefix.
+func (t *Trie) PrefixCount(prefix string) int {
+	node := t.find(prefix)
+	if node == nil {
+		return 0
+	}
+	return node.words
+}
+
+func (t *Trie) Delete(word string) bool {
+	path := t.path(word, false)
+	if path == nil || path[len(path)-1].count == 0 {
+		return false
+	}
+	path[len(path)-1].count = 0
+
+	runes := []rune(word)
+	for i := len(path) - 1; i >= 0; i-- {
+		node := path[i]
+		node.words -= 1
+		node.maxCount = node.count
+		for _, child := range node.children {
+			node.maxCount = max(node.maxCount, child.maxCount)
+		}
+		if i > 0 && node.words == 0 {
+			delete(path[i-1].children, runes[i-1])
+		}
+	}
+	return true
+}
+
+// Complete returns the n most frequent words starting with prefix, ordered
+// by count and then alphabetically like TopWords.
+func (t *Trie) Complete(prefix string, n int) []WordCount {
+	result := []WordCount{}
+	start := t.find(prefix)
+	if start == nil || n <= 0 {
+		return result
+	}
+
+	// Best-first search: a subtree is expanded only once its maxCount beats
+	// every word found so far. A prefix sorts before all words below it, so
+	// ties come out alphabetically too.
+	queue := &trieQueue{{key: prefix, node: start, priority: start.maxCount}}
+	for queue.Len() > 0 && len(result) < n {
+		item := heap.Pop(queue).(trieItem)
+		if item.node == nil {
+			result = append(result, WordCount{Word: item.key, Count: item.priority})
+			continue
+		}
+		if item.node.count > 0 {
+			heap.Push(queue, trieItem{key: item.key, priority: item.node.count})
+		}
+		for r, child := range item.node.children {
+			heap.Push(queue, trieItem{key: item.key + string(r), node: child, priority: child.maxCount})
+		}
+	}
+	return result
+}
+
+func (t *Trie) find(prefix string) *trieNode {
+	path := t.path(prefix, false)
+	if path == nil {
+		return nil
+	}
+	return path[len(path)-1]
+}
+
+// path returns the nodes from the root to word, creating missing ones if
+// create is set and returning nil otherwise
+func (t *Trie) path(word string, create bool) []*trieNode {
+	node := t.root
+	path := []*trieNode{node}
+	for _, r := range word {
+		child, found := node.children[r]
+		if !found {
+			if !create {
+				return nil
+			}
+			if node.children == nil {
+				node.children = map[rune]*trieNode{}
+			}
+			child = &trieNode{}
+			node.children[r] = child
+		}
+		node = child
+		path = append(path, node)
+	}
+	return path
+}
+
+// trieItem is a subtree to expand or, with a nil node, a finished word
+type trieItem struct {
+	key      string
+	node     *trieNode
+	priority int
+}
+
+type trieQueue []trieItem
+
+func (q trieQueue) Len() int { return len(q) }
+
+func (q trieQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.node == nil // A word before the subtree it ends
+}
+
+func (q trieQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *trieQueue) Push(x any) { *q = append(*q, x.(trieItem)) }
+
+func (q *trieQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,84 @@
+package words
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTrieComplete(t *testing.T) {
+	trie := NewTrie(map[string]int{
+		"go": 10, "gopher": 7, "goroutine": 7, "google": 3, "golang": 12, "rust": 20, "góра": 5,
+	})
+
+	result := trie.Complete("go", 4)
+	expected := []WordCount{{"golang", 12}, {"go", 10}, {"gopher", 7}, {"goroutine", 7}}
+	if !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+
+	if result := trie.Complete("gó", 5); !slices.Equal(result, []WordCount{{"góра", 5}}) {
+		t.Errorf("Expected [{góра 5}], got %v", result)
+	}
+	if result := trie.Complete("java", 5); len(result) != 0 {
+		t.Errorf("Expected no completions, got %v", result)
+	}
+
+	// The empty prefix matches TopWords over everything
+	all := map[string]int{"a": 1, "ab": 3, "abc": 3, "b": 2}
+	if result := NewTrie(all).Complete("", 10); !slices.Equal(result, TopWords(all, 10)) {
+		t.Errorf("Expected %v, got %v", TopWords(all, 10), result)
+	}
+}
+
+func TestTriePrefixCount(t *testing.T) {
+	trie := NewTrie(map[string]int{"go": 1, "gopher": 1, "golang": 1, "rust": 1})
+	trie.Add("go", 4)
+
+	if trie.Len() != 4 {
+		t.Errorf("Expected 4 words, got %d", trie.Len())
+	}
+	if c := trie.PrefixCount("go"); c != 3 {
+		t.Errorf("Expected 3 words under 'go', got %d", c)
+	}
+	if c := trie.PrefixCount("gop"); c != 1 {
+		t.Errorf("Expected 1 word under 'gop', got %d", c)
+	}
+	if c := trie.PrefixCount("x"); c != 0 {
+		t.Errorf("Expected 0 words under 'x', got %d", c)
+	}
+	if c := trie.Count("go"); c != 5 {
+		t.Errorf("Expected go=5, got %d", c)
+	}
+}
+
+func TestTrieDelete(t *testing.T) {
+	trie := NewTrie(map[string]int{"go": 10, "gopher": 50, "golang": 12})
+
+	if !trie.Delete("gopher") {
+		t.Error("Expected Delete to find 'gopher'")
+	}
+	if trie.Delete("gopher") || trie.Delete("gop") || trie.Delete("java") {
+		t.Error("Expected Delete to report missing words")
+	}
+	if trie.Count("gopher") != 0 || trie.PrefixCount("gop") != 0 {
+		t.Error("Expected 'gopher' to be gone")
+	}
+	if trie.Len() != 2 {
+		t.Errorf("Expected 2 words left, got %d", trie.Len())
+	}
+
+	// The deleted word's count no longer drives the search order
+	expected := []WordCount{{"golang", 12}, {"go", 10}}
+	if result := trie.Complete("g", 5); !slices.Equal(result, expected) {
+		t.Errorf("Expected %v, got %v", expected, result)
+	}
+	if trie.root.maxCount != 12 {
+		t.Errorf("Expected root maxCount 12, got %d", trie.root.maxCount)
+	}
+
+	// Deleting a word keeps words it is a prefix of
+	trie.Delete("go")
+	if trie.Count("golang") != 12 || trie.PrefixCount("go") != 1 {
+		t.Error("Expected 'golang' to survive deleting 'go'")
+	}
+}
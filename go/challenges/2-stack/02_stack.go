@@ -0,0 +1,183 @@
+package stack
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+type ErrorKind int
+
+const (
+	KindEmpty    ErrorKind = iota + 1 // No element to return
+	KindOverflow                      // No room for another element
+	KindClosed                        // The stack no longer accepts elements
+	KindCorrupt                       // The stack's contents are inconsistent
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case KindEmpty:
+		return "stack is empty"
+	case KindOverflow:
+		return "stack is full"
+	case KindClosed:
+		return "stack is closed"
+	case KindCorrupt:
+		return "stack is corrupt"
+	}
+	return "unknown error"
+}
+
+// Sentinels for errors.Is. They match any StackError of the same kind.
+var (
+	ErrEmpty    error = &StackError{Kind: KindEmpty}
+	ErrOverflow error = &StackError{Kind: KindOverflow}
+	ErrClosed   error = &StackError{Kind: KindClosed}
+	ErrCorrupt  error = &StackError{Kind: KindCorrupt}
+)
+
+type StackError struct {
+	Op   string // Method that failed, e.g. "Pop"
+	Kind ErrorKind
+	Err  error // Underlying cause, if any
+}
+
+func (se *StackError) Error() string {
+	msg := "stack error: "
+	if se.Op != "" {
+		msg += se.Op + ": "
+	}
+	msg += se.Kind.String()
+	if se.Err != nil {
+		msg += ": " + se.Err.Error()
+	}
+	return msg
+}
+
+func (se *StackError) Unwrap() error {
+	return se.Err
+}
+
+// Is matches a sentinel, or any StackError without an Op or cause, by kind.
+func (se *StackError) Is(target error) bool {
+	t, ok := target.(*StackError)
+	return ok && t.Op == "" && t.Err == nil && t.Kind == se.Kind
+}
+
+func NewStackError(op string, kind ErrorKind, err error) error {
+	return &StackError{Op: op, Kind: kind, Err: err}
+}
+
+type Stack[T any] struct {
+	elements []T
+}
+
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+func (s *Stack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+func (s *Stack[T]) Pop() (T, error) {
+	var e T
+	if s.Size() == 0 {
+		return e, NewStackError("Pop", KindEmpty, nil)
+	}
+	e, s.elements = s.elements[s.Size()-1], s.elements[:s.Size()-1]
+	return e, nil
+}
+
+func (s *Stack[T]) Push(p T) *Stack[T] {
+	s.elements = append(s.elements, p)
+	return s
+}
+
+func (s *Stack[T]) Size() int {
+	return len(s.elements)
+}
+
+func (s *Stack[T]) Peek() (T, error) {
+	if s.Size() == 0 {
+		return *new(T), NewStackError("Peek", KindEmpty, nil)
+	}
+	return s.elements[len(s.elements)-1], nil
+}
+
+func (s *Stack[T]) String() string {
+	str := []string{}
+	for _, e := range s.elements {
+		str = append(str, fmt.Sprintf("%v", e))
+	}
+	return "{" + strings.Join(str, " ") + "}"
+}
+
+func (s *Stack[T]) Clear() *Stack[T] {
+	s.elements = s.elements[:0] // Reuse underlying array
+	return s
+}
+
+// All iterates from the top of the stack to the bottom. The index is the
+// distance from the top. It visits the elements present when the loop
+// starts, so popping inside the loop is safe.
+func (s *Stack[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		elements := s.elements
+		for i := range elements {
+			if !yield(i, elements[len(elements)-1-i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates from the bottom of the stack to the top, with the same
+// indexes as All.
+func (s *Stack[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		elements := s.elements
+		for i, e := range elements {
+			if !yield(len(elements)-1-i, e) {
+				return
+			}
+		}
+	}
+}
+
+// Drain pops and yields elements until the stack is empty or the loop
+// stops.
+func (s *Stack[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !s.IsEmpty() {
+			e, _ := s.Pop()
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// PushAll pushes every element of seq, so the last one ends on top.
+func (s *Stack[T]) PushAll(seq iter.Seq[T]) *Stack[T] {
+	for e := range seq {
+		s.Push(e)
+	}
+	return s
+}
+
+func (s *Stack[T]) Clone() *Stack[T] {
+	return &Stack[T]{elements: slices.Clone(s.elements)}
+}
+
+// FromSlice returns a stack of a copy of elements, with the last on top.
+func FromSlice[T any](elements []T) *Stack[T] {
+	return &Stack[T]{elements: slices.Clone(elements)}
+}
+
+// ToSlice returns a copy of the elements, bottom first like String.
+func (s *Stack[T]) ToSlice() []T {
+	return slices.Clone(s.elements)
+}
@@ -3,7 +3,8 @@ package stack
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"maps"
+	"slices"
 	"testing"
 )
 
@@ -72,67 +73,133 @@ func TestStackClear(t *testing.T) {
 	}
 }
 
-/* ------------- IMPLEMENTATIONS ------------ */
-
-type StackError struct {
-	message string
-}
-
-func (se *StackError) Error() string {
-	return "stack error: " + se.message
+func TestStackErrorKinds(t *testing.T) {
+	stack := NewStack[int]()
+	_, popErr := stack.Pop()
+	_, peekErr := stack.Peek()
+
+	for op, err := range map[string]error{"Pop": popErr, "Peek": peekErr} {
+		var stackErr *StackError
+		if !errors.As(err, &stackErr) || stackErr.Op != op || stackErr.Kind != KindEmpty {
+			t.Errorf("Expected an empty StackError from %s, got %v", op, err)
+		}
+		if !errors.Is(err, ErrEmpty) {
+			t.Errorf("%s error should match ErrEmpty", op)
+		}
+		if errors.Is(err, ErrOverflow) {
+			t.Errorf("%s error should not match ErrOverflow", op)
+		}
+	}
+	if popErr.Error() != "stack error: Pop: stack is empty" {
+		t.Errorf("Unexpected message %q", popErr.Error())
+	}
 }
 
-func NewStackError(message string) error {
-	err := &StackError{message: message}
-	return err
-}
+func TestStackErrorWrapping(t *testing.T) {
+	cause := errors.New("disk full")
+	err := fmt.Errorf("saving: %w", NewStackError("Save", KindCorrupt, cause))
 
-type Stack[T any] struct {
-	elements []T
+	if !errors.Is(err, ErrCorrupt) || !errors.Is(err, cause) {
+		t.Errorf("Expected to match both ErrCorrupt and the cause, got %v", err)
+	}
+	if err.Error() != "saving: stack error: Save: stack is corrupt: disk full" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+	var stackErr *StackError
+	if !errors.As(err, &stackErr) || stackErr.Unwrap() != cause {
+		t.Errorf("Expected Unwrap to return the cause, got %v", stackErr)
+	}
 }
 
-func NewStack[T any]() *Stack[T] {
-	return &Stack[T]{}
-}
+func TestStackIterators(t *testing.T) {
+	stack := FromSlice([]int{1, 2, 3})
 
-func (s *Stack[T]) IsEmpty() bool {
-	return s.Size() == 0
-}
+	if result := maps.Collect(stack.All()); !maps.Equal(result, map[int]int{0: 3, 1: 2, 2: 1}) {
+		t.Errorf("Expected map[0:3 1:2 2:1], got %v", result)
+	}
+	index, values := []int{}, []int{}
+	for i, e := range stack.All() {
+		index, values = append(index, i), append(values, e)
+	}
+	if !slices.Equal(index, []int{0, 1, 2}) || !slices.Equal(values, []int{3, 2, 1}) {
+		t.Errorf("Expected All to go top to bottom, got %v %v", index, values)
+	}
+	index, values = index[:0], values[:0]
+	for i, e := range stack.Backward() {
+		index, values = append(index, i), append(values, e)
+	}
+	if !slices.Equal(index, []int{2, 1, 0}) || !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Expected Backward to go bottom to top, got %v %v", index, values)
+	}
+	for range stack.All() {
+		break // Stopping early must not panic
+	}
+	if stack.Size() != 3 {
+		t.Error("Iterating should not change the stack")
+	}
 
-func (s *Stack[T]) Pop() (T, error) {
-	var e T
-	if s.Size() == 0 {
-		return e, NewStackError("no elements in stack")
+	// Popping during iteration still visits the original elements
+	popping := stack.Clone()
+	values = values[:0]
+	for _, e := range popping.All() {
+		popping.Pop()
+		values = append(values, e)
+	}
+	if !slices.Equal(values, []int{3, 2, 1}) || !popping.IsEmpty() {
+		t.Errorf("Expected All to visit [3 2 1] while popping, got %v", values)
+	}
+	popping = stack.Clone()
+	values = values[:0]
+	for _, e := range popping.Backward() {
+		popping.Pop()
+		values = append(values, e)
+	}
+	if !slices.Equal(values, []int{1, 2, 3}) || !popping.IsEmpty() {
+		t.Errorf("Expected Backward to visit [1 2 3] while popping, got %v", values)
 	}
-	e, s.elements = s.elements[s.Size()-1], s.elements[:s.Size()-1]
-	return e, nil
 }
 
-func (s *Stack[T]) Push(p T) *Stack[T] {
-	s.elements = append(s.elements, p)
-	return s
+func TestStackDrain(t *testing.T) {
+	stack := FromSlice([]string{"a", "b", "c"})
+	for e := range stack.Drain() {
+		if e == "b" {
+			break
+		}
+	}
+	if stack.String() != "{a}" {
+		t.Errorf("Expected elements up to the break to be popped, got %s", stack)
+	}
+	if result := slices.Collect(stack.Drain()); !slices.Equal(result, []string{"a"}) || !stack.IsEmpty() {
+		t.Errorf("Expected [a] and an empty stack, got %v and %s", result, stack)
+	}
 }
 
-func (s *Stack[T]) Size() int {
-	return len(s.elements)
-}
+func TestStackSlicesAndClone(t *testing.T) {
+	elements := []int{1, 2, 3}
+	stack := FromSlice(elements)
+	elements[0] = 100
+	if stack.String() != "{1 2 3}" {
+		t.Errorf("FromSlice should copy, got %s", stack)
+	}
+	if top, _ := stack.Peek(); top != 3 {
+		t.Errorf("Expected the last element on top, got %d", top)
+	}
 
-func (s *Stack[T]) Peek() (T, error) {
-	if s.Size() == 0 {
-		return *new(T), fmt.Errorf("stack is empty")
+	result := stack.ToSlice()
+	result[0] = 100
+	if !slices.Equal(stack.ToSlice(), []int{1, 2, 3}) {
+		t.Errorf("ToSlice should copy, got %v", stack.ToSlice())
 	}
-	return s.elements[len(s.elements)-1], nil
-}
 
-func (s *Stack[T]) String() string {
-	str := []string{}
-	for _, e := range s.elements {
-		str = append(str, fmt.Sprintf("%v", e))
+	clone := stack.Clone()
+	clone.Push(4)
+	stack.Pop()
+	if clone.String() != "{1 2 3 4}" || stack.String() != "{1 2}" {
+		t.Errorf("Expected independent stacks, got %s and %s", clone, stack)
 	}
-	return "{" + strings.Join(str, " ") + "}"
-}
 
-func (s *Stack[T]) Clear() *Stack[T] {
-	s.elements = s.elements[:0] // Reuse underlying array
-	return s
+	pushed := NewStack[int]().PushAll(slices.Values([]int{5, 6})).PushAll(slices.Values(stack.ToSlice()))
+	if pushed.String() != "{5 6 1 2}" {
+		t.Errorf("Expected {5 6 1 2}, got %s", pushed)
+	}
 }
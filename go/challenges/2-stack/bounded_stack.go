@@ -0,0 +1,196 @@
+package stack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// OverflowPolicy decides what Push does when a BoundedStack is full.
+type OverflowPolicy int
+
+const (
+	OverflowError OverflowPolicy = iota // Return a StackError
+	DropOldest                          // Discard the bottom element
+	Block                               // Wait until an element is popped
+)
+
+// BoundedStack holds at most a fixed number of elements in a ring buffer,
+// so dropping the oldest element is as cheap as pushing. It is safe for
+// concurrent use.
+type BoundedStack[T any] struct {
+	mu       sync.Mutex
+	elements []T
+	bottom   int // Index of the oldest element
+	size     int
+	policy   OverflowPolicy
+	closed   bool
+	changed  chan struct{} // Closed and replaced whenever the stack changes
+}
+
+func NewBoundedStack[T any](capacity int, policy OverflowPolicy) *BoundedStack[T] {
+	if capacity <= 0 {
+		panic("stack: capacity must be positive")
+	}
+	return &BoundedStack[T]{
+		elements: make([]T, capacity),
+		policy:   policy,
+		changed:  make(chan struct{}),
+	}
+}
+
+func (s *BoundedStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Push adds p, applying the overflow policy if the stack is full. With
+// Block it waits indefinitely; use PushCtx to give up.
+func (s *BoundedStack[T]) Push(p T) error {
+	return s.push(context.Background(), p, "Push")
+}
+
+// PushCtx is Push that stops waiting for space when ctx is done, returning
+// an overflow error that wraps ctx.Err(). The OverflowError and DropOldest
+// policies never wait.
+func (s *BoundedStack[T]) PushCtx(ctx context.Context, p T) error {
+	return s.push(ctx, p, "PushCtx")
+}
+
+func (s *BoundedStack[T]) push(ctx context.Context, p T, op string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for {
+		if s.closed {
+			return NewStackError(op, KindClosed, nil)
+		}
+		if s.size < len(s.elements) {
+			break
+		}
+		switch s.policy {
+		case OverflowError:
+			return NewStackError(op, KindOverflow, nil)
+		case DropOldest:
+			s.elements[s.bottom] = *new(T)
+			s.bottom = (s.bottom + 1) % len(s.elements)
+			s.size--
+		default:
+			if err := s.wait(ctx); err != nil {
+				return NewStackError(op, KindOverflow, err)
+			}
+		}
+	}
+	s.elements[s.index(s.size)] = p
+	s.size++
+	s.notify()
+	return nil
+}
+
+// Pop removes the top element, returning an error if there is none.
+func (s *BoundedStack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size == 0 {
+		return *new(T), NewStackError("Pop", KindEmpty, nil)
+	}
+	return s.pop(), nil
+}
+
+// PopCtx waits for an element if the stack is empty, until ctx is done or
+// the stack is closed. Giving up on ctx returns an empty error that wraps
+// ctx.Err().
+func (s *BoundedStack[T]) PopCtx(ctx context.Context) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.size == 0 {
+		if s.closed {
+			return *new(T), NewStackError("PopCtx", KindClosed, nil)
+		}
+		if err := s.wait(ctx); err != nil {
+			return *new(T), NewStackError("PopCtx", KindEmpty, err)
+		}
+	}
+	return s.pop(), nil
+}
+
+// Close stops the stack accepting elements and wakes blocked callers.
+// Elements already pushed can still be popped.
+func (s *BoundedStack[T]) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		s.notify()
+	}
+}
+
+func (s *BoundedStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+func (s *BoundedStack[T]) Cap() int {
+	return len(s.elements)
+}
+
+func (s *BoundedStack[T]) Peek() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.size == 0 {
+		return *new(T), NewStackError("Peek", KindEmpty, nil)
+	}
+	return s.elements[s.index(s.size-1)], nil
+}
+
+func (s *BoundedStack[T]) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	str := []string{}
+	for i := range s.size {
+		str = append(str, fmt.Sprintf("%v", s.elements[s.index(i)]))
+	}
+	return "{" + strings.Join(str, " ") + "}"
+}
+
+func (s *BoundedStack[T]) Clear() *BoundedStack[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.elements) // Drop references so they can be collected
+	s.bottom, s.size = 0, 0
+	s.notify()
+	return s
+}
+
+// index maps a position counted from the bottom to the ring buffer
+func (s *BoundedStack[T]) index(i int) int {
+	return (s.bottom + i) % len(s.elements)
+}
+
+func (s *BoundedStack[T]) pop() T {
+	i := s.index(s.size - 1)
+	e := s.elements[i]
+	s.elements[i] = *new(T)
+	s.size--
+	s.notify()
+	return e
+}
+
+// wait releases the lock until the stack changes or ctx is done. Callers
+// hold the lock and must recheck their condition afterwards.
+func (s *BoundedStack[T]) wait(ctx context.Context) error {
+	changed := s.changed
+	s.mu.Unlock()
+	defer s.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *BoundedStack[T]) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
@@ -0,0 +1,186 @@
+package stack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedStackOverflowError(t *testing.T) {
+	stack := NewBoundedStack[int](2, OverflowError)
+	stack.Push(1)
+	stack.Push(2)
+
+	err := stack.Push(3)
+	var stackErr *StackError
+	if !errors.As(err, &stackErr) || stackErr.Op != "Push" || !errors.Is(err, ErrOverflow) {
+		t.Errorf("Push on a full stack should return an overflow StackError, got %v", err)
+	}
+	if stack.String() != "{1 2}" {
+		t.Errorf("Expected {1 2}, got %s", stack)
+	}
+	if stack.Size() != 2 || stack.Cap() != 2 {
+		t.Errorf("Expected size 2 and cap 2, got %d and %d", stack.Size(), stack.Cap())
+	}
+}
+
+func TestBoundedStackDropOldest(t *testing.T) {
+	stack := NewBoundedStack[int](3, DropOldest)
+	for i := 1; i <= 5; i++ {
+		if err := stack.Push(i); err != nil {
+			t.Fatalf("Push returned error: %v", err)
+		}
+	}
+	if stack.String() != "{3 4 5}" {
+		t.Errorf("Expected {3 4 5}, got %s", stack)
+	}
+	for _, expected := range []int{5, 4} {
+		if e, err := stack.Pop(); err != nil || e != expected {
+			t.Errorf("Expected %d, got %v with error %v", expected, e, err)
+		}
+	}
+	// Wrap around the ring again after popping
+	stack.Push(6)
+	stack.Push(7)
+	stack.Push(8)
+	if top, _ := stack.Peek(); stack.String() != "{6 7 8}" || top != 8 {
+		t.Errorf("Expected {6 7 8} with 8 on top, got %s with %d", stack, top)
+	}
+
+	stack.Clear()
+	if !stack.IsEmpty() {
+		t.Error("Stack should be empty after Clear")
+	}
+	if _, err := stack.Pop(); err == nil {
+		t.Error("Pop from empty stack should return error")
+	}
+}
+
+func TestBoundedStackBlock(t *testing.T) {
+	stack := NewBoundedStack[int](1, Block)
+	stack.Push(1)
+
+	pushed := make(chan error)
+	go func() {
+		pushed <- stack.Push(2)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("Push on a full stack should block")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if e, err := stack.Pop(); err != nil || e != 1 {
+		t.Errorf("Expected 1, got %v with error %v", e, err)
+	}
+	if err := <-pushed; err != nil {
+		t.Errorf("Blocked Push returned error: %v", err)
+	}
+	if stack.String() != "{2}" {
+		t.Errorf("Expected {2}, got %s", stack)
+	}
+}
+
+func TestBoundedStackContext(t *testing.T) {
+	stack := NewBoundedStack[int](1, Block)
+	stack.Push(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := stack.PushCtx(ctx, 2); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrOverflow) {
+		t.Errorf("Expected an overflow wrapping deadline exceeded, got %v", err)
+	}
+
+	stack.Pop()
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := stack.PopCtx(ctx); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected empty wrapping deadline exceeded, got %v", err)
+	}
+
+	// PopCtx waits for a push
+	popped := make(chan int)
+	go func() {
+		e, _ := stack.PopCtx(context.Background())
+		popped <- e
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stack.Push(3)
+	if e := <-popped; e != 3 {
+		t.Errorf("Expected 3, got %d", e)
+	}
+}
+
+func TestBoundedStackClose(t *testing.T) {
+	stack := NewBoundedStack[int](1, Block)
+	stack.Push(1)
+
+	blocked := make(chan error)
+	go func() {
+		blocked <- stack.Push(2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	stack.Close()
+	if err := <-blocked; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected a blocked Push to fail with ErrClosed, got %v", err)
+	}
+
+	// Remaining elements drain, then PopCtx reports the close
+	if e, err := stack.PopCtx(context.Background()); err != nil || e != 1 {
+		t.Errorf("Expected 1, got %v with error %v", e, err)
+	}
+	if _, err := stack.PopCtx(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed from an empty closed stack, got %v", err)
+	}
+	if _, err := stack.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from Pop, got %v", err)
+	}
+	stack.Close() // Closing twice is harmless
+}
+
+// Producers and consumers share a small blocking stack; everything pushed
+// must be popped exactly once. Run with -race.
+func TestBoundedStackProducersConsumers(t *testing.T) {
+	const producers, perProducer = 4, 500
+	stack := NewBoundedStack[int](8, Block)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	seen := map[int]int{}
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				if err := stack.PushCtx(ctx, p*perProducer+i); err != nil {
+					t.Errorf("PushCtx returned error: %v", err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range perProducer {
+				e, err := stack.PopCtx(ctx)
+				if err != nil {
+					t.Errorf("PopCtx returned error: %v", err)
+				}
+				mu.Lock()
+				seen[e]++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != producers*perProducer {
+		t.Errorf("Expected %d distinct values, got %d", producers*perProducer, len(seen))
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Errorf("Expected %d to be popped once, got %d", v, n)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package stack
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// ConcurrentStack is a lock-free Treiber stack: the top is an atomic
+// pointer to an immutable linked list, swapped with compare-and-swap.
+type ConcurrentStack[T any] struct {
+	top atomic.Pointer[node[T]]
+}
+
+// node is an immutable list cell, so ConcurrentStack can swap tops safely
+// and PStack versions can share tails.
+type node[T any] struct {
+	value T
+	next  *node[T]
+	size  int // Elements from this node down, so Size needs no counter
+}
+
+func NewConcurrentStack[T any]() *ConcurrentStack[T] {
+	return &ConcurrentStack[T]{}
+}
+
+func (s *ConcurrentStack[T]) IsEmpty() bool {
+	return s.top.Load() == nil
+}
+
+func (s *ConcurrentStack[T]) Push(p T) *ConcurrentStack[T] {
+	n := &node[T]{value: p}
+	for {
+		top := s.top.Load()
+		n.next, n.size = top, top.len()+1
+		if s.top.CompareAndSwap(top, n) {
+			return s
+		}
+	}
+}
+
+func (s *ConcurrentStack[T]) Pop() (T, error) {
+	for {
+		top := s.top.Load()
+		if top == nil {
+			return *new(T), NewStackError("Pop", KindEmpty, nil)
+		}
+		if s.top.CompareAndSwap(top, top.next) {
+			return top.value, nil
+		}
+	}
+}
+
+func (s *ConcurrentStack[T]) Size() int {
+	return s.top.Load().len()
+}
+
+func (s *ConcurrentStack[T]) Peek() (T, error) {
+	top := s.top.Load()
+	if top == nil {
+		return *new(T), NewStackError("Peek", KindEmpty, nil)
+	}
+	return top.value, nil
+}
+
+func (s *ConcurrentStack[T]) String() string {
+	return s.top.Load().String()
+}
+
+func (s *ConcurrentStack[T]) Clear() *ConcurrentStack[T] {
+	s.top.Store(nil)
+	return s
+}
+
+func (n *node[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// String shows the list from n down, bottom first like Stack.String.
+func (n *node[T]) String() string {
+	str := make([]string, n.len())
+	for i := len(str) - 1; n != nil; n, i = n.next, i-1 {
+		str[i] = fmt.Sprintf("%v", n.value)
+	}
+	return "{" + strings.Join(str, " ") + "}"
+}
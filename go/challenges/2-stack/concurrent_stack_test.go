@@ -0,0 +1,137 @@
+package stack
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConcurrentStackBasicOperations(t *testing.T) {
+	stack := NewConcurrentStack[int]()
+
+	if !stack.IsEmpty() {
+		t.Error("New stack should be empty")
+	}
+	_, err := stack.Pop()
+	if !errors.Is(err, ErrEmpty) {
+		t.Errorf("Pop from empty stack should return ErrEmpty, got %v", err)
+	}
+	if _, err := stack.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Peek on empty stack should return ErrEmpty, got %v", err)
+	}
+
+	stack.Push(1).Push(2).Push(3)
+	if stack.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", stack.Size())
+	}
+	top, err := stack.Peek()
+	if err != nil || top != 3 {
+		t.Errorf("Expected top to be 3, got %v with error %v", top, err)
+	}
+	if stack.String() != "{1 2 3}" {
+		t.Errorf("Expected {1 2 3}, got %s", stack.String())
+	}
+
+	for _, expected := range []int{3, 2, 1} {
+		if e, err := stack.Pop(); err != nil || e != expected {
+			t.Errorf("Expected %d, got %v with error %v", expected, e, err)
+		}
+	}
+	if !stack.IsEmpty() || stack.Size() != 0 {
+		t.Error("Stack should be empty after popping everything")
+	}
+}
+
+func TestConcurrentStackClear(t *testing.T) {
+	stack := NewConcurrentStack[string]()
+	stack.Push("hello").Push("world")
+
+	if stack.Clear() != stack {
+		t.Error("Clear should return the same stack instance")
+	}
+	if !stack.IsEmpty() || stack.String() != "{}" {
+		t.Errorf("Stack should be empty after Clear, got %s", stack)
+	}
+}
+
+// Every pushed value must come out exactly once, whether popped while the
+// pushers run or left on the stack at the end. Run with -race.
+func TestConcurrentStackStress(t *testing.T) {
+	const goroutines, perGoroutine = 8, 2000
+	stack := NewConcurrentStack[int]()
+	seen := make([]atomic.Int32, goroutines*perGoroutine)
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := range perGoroutine {
+				stack.Push(g*perGoroutine + i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range perGoroutine / 2 {
+				if e, err := stack.Pop(); err == nil {
+					seen[e].Add(1)
+				}
+				stack.Peek()
+				stack.Size()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for !stack.IsEmpty() {
+		e, err := stack.Pop()
+		if err != nil {
+			t.Fatalf("Pop returned error on a non-empty stack: %v", err)
+		}
+		seen[e].Add(1)
+	}
+	for v := range seen {
+		if n := seen[v].Load(); n != 1 {
+			t.Fatalf("Expected %d to be popped once, got %d", v, n)
+		}
+	}
+}
+
+// lockedStack is the obvious alternative: a Stack behind a mutex.
+type lockedStack[T any] struct {
+	mu    sync.Mutex
+	stack *Stack[T]
+}
+
+func (s *lockedStack[T]) Push(e T) {
+	s.mu.Lock()
+	s.stack.Push(e)
+	s.mu.Unlock()
+}
+
+func (s *lockedStack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+func BenchmarkConcurrentStack(b *testing.B) {
+	stack := NewConcurrentStack[int]()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func BenchmarkMutexStack(b *testing.B) {
+	stack := &lockedStack[int]{stack: NewStack[int]()}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
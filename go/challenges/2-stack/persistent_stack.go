@@ -0,0 +1,48 @@
+package stack
+
+// PStack is an immutable stack. Push and Pop return new versions in O(1)
+// that share their tails with the original, which stays valid; the zero
+// value is an empty stack.
+type PStack[T any] struct {
+	top *node[T]
+}
+
+func NewPStack[T any]() PStack[T] {
+	return PStack[T]{}
+}
+
+func (s PStack[T]) IsEmpty() bool {
+	return s.top == nil
+}
+
+func (s PStack[T]) Push(p T) PStack[T] {
+	return PStack[T]{top: &node[T]{value: p, next: s.top, size: s.top.len() + 1}}
+}
+
+// Pop returns the top element and the stack below it.
+func (s PStack[T]) Pop() (T, PStack[T], error) {
+	if s.top == nil {
+		return *new(T), s, NewStackError("Pop", KindEmpty, nil)
+	}
+	return s.top.value, PStack[T]{top: s.top.next}, nil
+}
+
+func (s PStack[T]) Size() int {
+	return s.top.len()
+}
+
+func (s PStack[T]) Peek() (T, error) {
+	if s.top == nil {
+		return *new(T), NewStackError("Peek", KindEmpty, nil)
+	}
+	return s.top.value, nil
+}
+
+func (s PStack[T]) String() string {
+	return s.top.String()
+}
+
+// Clear returns an empty stack; s is unchanged.
+func (s PStack[T]) Clear() PStack[T] {
+	return PStack[T]{}
+}
@@ -0,0 +1,96 @@
+package stack
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPStackBasicOperations(t *testing.T) {
+	empty := NewPStack[int]()
+	if !empty.IsEmpty() {
+		t.Error("New stack should be empty")
+	}
+	if _, _, err := empty.Pop(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Pop from empty stack should return ErrEmpty, got %v", err)
+	}
+	if _, err := empty.Peek(); !errors.Is(err, ErrEmpty) {
+		t.Errorf("Peek on empty stack should return ErrEmpty, got %v", err)
+	}
+
+	stack := empty.Push(1).Push(2).Push(3)
+	if stack.Size() != 3 || stack.String() != "{1 2 3}" {
+		t.Errorf("Expected {1 2 3} of size 3, got %s of size %d", stack, stack.Size())
+	}
+	top, err := stack.Peek()
+	if err != nil || top != 3 {
+		t.Errorf("Expected top to be 3, got %v with error %v", top, err)
+	}
+
+	e, rest, err := stack.Pop()
+	if err != nil || e != 3 || rest.String() != "{1 2}" {
+		t.Errorf("Expected 3 and {1 2}, got %v and %s with error %v", e, rest, err)
+	}
+	if !stack.Clear().IsEmpty() {
+		t.Error("Clear should return an empty stack")
+	}
+}
+
+func TestPStackVersions(t *testing.T) {
+	base := NewPStack[string]().Push("a").Push("b")
+	left := base.Push("left")
+	_, popped, _ := base.Pop()
+	right := popped.Push("right")
+
+	// Every version keeps its own contents
+	versions := []struct {
+		stack    PStack[string]
+		expected string
+	}{
+		{base, "{a b}"}, {left, "{a b left}"}, {popped, "{a}"}, {right, "{a right}"},
+	}
+	for _, v := range versions {
+		if v.stack.String() != v.expected {
+			t.Errorf("Expected %s, got %s", v.expected, v.stack)
+		}
+	}
+	base.Clear()
+	if base.String() != "{a b}" {
+		t.Errorf("Clear should not change the original, got %s", base)
+	}
+
+	// Versions share their tails instead of copying
+	if left.top.next != base.top || right.top.next != popped.top || popped.top != base.top.next {
+		t.Error("Expected versions to share tails")
+	}
+}
+
+// A backtracking search keeps the path as a PStack, so undoing a step is
+// just going back to the previous version.
+func TestPStackBacktracking(t *testing.T) {
+	var solutions []string
+	var solve func(path PStack[int], remaining int)
+	solve = func(path PStack[int], remaining int) {
+		if remaining == 0 {
+			solutions = append(solutions, path.String())
+			return
+		}
+		last, err := path.Peek()
+		if err != nil {
+			last = 1
+		}
+		for step := last; step <= remaining; step++ {
+			solve(path.Push(step), remaining-step)
+		}
+	}
+	solve(NewPStack[int](), 4)
+
+	expected := []string{"{1 1 1 1}", "{1 1 2}", "{1 3}", "{2 2}", "{4}"}
+	if len(solutions) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, solutions)
+	}
+	for i := range expected {
+		if solutions[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, solutions)
+		}
+	}
+}